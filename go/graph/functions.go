@@ -254,27 +254,28 @@ func (g *Graph) MinimumSpanningTree() []Edge {
 	if g.Kind == Directed {
 		return nil
 	}
-	// create priority queue for vertices
-	// node.data is the index into the heap
+	// create priority queue for vertices, on a binary heap since edge
+	// weights may be negative, which RadixHeap cannot take
 	// node.state is the weight of an edge to its parent
-	nodesBase := nodeSlice(make([]*node, len(g.nodes)))
-	copy(nodesBase, g.nodes)
-	for i := range nodesBase {
-		nodesBase[i].state = 1<<31 - 1
-		nodesBase[i].data = i
+	nodes := newNodeQueue(BinaryHeap, len(g.nodes))
+	for i := range g.nodes {
+		g.nodes[i].state = 1<<31 - 1
+	}
+	g.nodes[0].state = 0
+	g.nodes[0].parent = nil
+	for i := range g.nodes {
+		nodes.push(g.nodes[i])
 	}
-	nodesBase[0].state = 0
-	nodesBase[0].parent = nil
-	nodes := &nodesBase
-	nodes.heapInit()
 
-	for len(*nodes) > 0 {
+	for nodes.len() > 0 {
 		min := nodes.pop()
+		min.data = dequeued // see determineCluster
 		for _, edge := range min.edges {
 			v := edge.end // get the other side of the edge
-			if nodes.heapContains(v) && edge.weight < v.state {
+			if nodes.contains(v) && edge.weight < v.state {
 				v.parent = min
-				nodes.update(v.data, edge.weight)
+				v.state = edge.weight
+				nodes.decrease(v)
 			}
 		}
 	}
@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"github.com/twmb/algoimpl/go/tree/heap"
+)
+
+// HeapKind chooses the priority queue that DijkstraSearchHeap runs on.
+type HeapKind int
+
+const (
+	// An indexed binary heap. DecreaseKey is O(lg n), giving
+	// O((E + V) lg V), but it has the smallest constant factors.
+	BinaryHeap HeapKind = iota
+	// A Fibonacci heap. DecreaseKey is amortized O(1), giving
+	// O(E + V lg V), which wins on very dense graphs.
+	FibonacciHeap
+	// An indexed 4-ary heap. It is shallower than a binary heap,
+	// which usually makes it faster on large graphs.
+	QuaternaryHeap
+	// A radix heap. Keys are bucketed by their bits instead of being
+	// compared, which is fastest when edge weights are small integers.
+	RadixHeap
+)
+
+// nodeQueue is a min priority queue of nodes ordered by node.state.
+// decrease must be called after lowering the state of a queued node.
+type nodeQueue interface {
+	len() int
+	push(n *node)
+	pop() *node
+	contains(n *node) bool
+	decrease(n *node)
+}
+
+func lessState(a, b *node) bool {
+	return a.state < b.state
+}
+
+func newNodeQueue(kind HeapKind, size int) nodeQueue {
+	switch kind {
+	case FibonacciHeap:
+		return &fibonacciQueue{heap.NewFibonacci(lessState),
+			make([]*heap.FibonacciNode[*node], size), make([]bool, size)}
+	case QuaternaryHeap:
+		return &indexedQueue{heap.NewIndexedDary(4, lessState), make([]*heap.Element[*node], size)}
+	case RadixHeap:
+		return &radixQueue{h: heap.NewRadix[*node](), queued: make([]bool, size)}
+	}
+	return &indexedQueue{heap.NewIndexed(lessState), make([]*heap.Element[*node], size)}
+}
+
+// elems is indexed by node.index
+type indexedQueue struct {
+	h     *heap.Indexed[*node]
+	elems []*heap.Element[*node]
+}
+
+func (q *indexedQueue) len() int              { return q.h.Len() }
+func (q *indexedQueue) push(n *node)          { q.elems[n.index] = q.h.Push(n) }
+func (q *indexedQueue) pop() *node            { return q.h.Pop() }
+func (q *indexedQueue) contains(n *node) bool { return q.h.Contains(q.elems[n.index]) }
+func (q *indexedQueue) decrease(n *node)      { q.h.DecreaseKey(q.elems[n.index], n) }
+
+// nodes and queued are indexed by node.index
+type fibonacciQueue struct {
+	h      *heap.FibonacciHeap[*node]
+	nodes  []*heap.FibonacciNode[*node]
+	queued []bool
+}
+
+func (q *fibonacciQueue) len() int { return q.h.Len() }
+func (q *fibonacciQueue) push(n *node) {
+	q.nodes[n.index] = q.h.Insert(n)
+	q.queued[n.index] = true
+}
+func (q *fibonacciQueue) pop() *node {
+	n := q.h.ExtractMin()
+	q.queued[n.index] = false
+	return n
+}
+func (q *fibonacciQueue) contains(n *node) bool { return q.queued[n.index] }
+func (q *fibonacciQueue) decrease(n *node)      { q.h.DecreaseKey(q.nodes[n.index], n) }
+
+// A radix heap has no decrease key, so decrease pushes the node again
+// with its new state and pop skips entries whose key is stale.
+// Node states must be nonnegative.
+type radixQueue struct {
+	h      *heap.RadixHeap[*node]
+	queued []bool // indexed by node.index
+	n      int
+}
+
+func (q *radixQueue) len() int { return q.n }
+func (q *radixQueue) push(n *node) {
+	q.h.Push(uint64(n.state), n)
+	q.queued[n.index] = true
+	q.n++
+}
+func (q *radixQueue) pop() *node {
+	for {
+		key, n := q.h.Pop()
+		if q.queued[n.index] && key == uint64(n.state) {
+			q.queued[n.index] = false
+			q.n--
+			return n
+		}
+	}
+}
+func (q *radixQueue) contains(n *node) bool { return q.queued[n.index] }
+func (q *radixQueue) decrease(n *node)      { q.h.Push(uint64(n.state), n) }
@@ -9,23 +9,28 @@ type Path struct {
 // node in the graph. All edges must have a positive weight, otherwise this
 // function will return nil.
 func (g *Graph) DijkstraSearch(start Node) []Path {
+	return g.DijkstraSearchHeap(start, BinaryHeap)
+}
+
+// DijkstraSearchHeap is DijkstraSearch running on the given kind of priority queue.
+func (g *Graph) DijkstraSearchHeap(start Node, kind HeapKind) []Path {
 	if start.node == nil || g.nodes[start.node.index] != start.node {
 		return nil
 	}
 	paths := make([]Path, len(g.nodes))
 
-	nodesBase := nodeSlice(make([]*node, len(g.nodes)))
-	copy(nodesBase, g.nodes)
-	for i := range nodesBase {
-		nodesBase[i].state = 1<<31 - 1
-		nodesBase[i].data = i
-		nodesBase[i].parent = nil
+	// node.state is the distance from start
+	nodes := newNodeQueue(kind, len(g.nodes))
+	for i := range g.nodes {
+		g.nodes[i].state = 1<<31 - 1
+		g.nodes[i].parent = nil
 	}
 	start.node.state = 0 // make it so 'start' sorts to the top of the heap
-	nodes := &nodesBase
-	nodes.heapInit()
+	for i := range g.nodes {
+		nodes.push(g.nodes[i])
+	}
 
-	for len(*nodes) > 0 {
+	for nodes.len() > 0 {
 		curNode := nodes.pop()
 		for _, edge := range curNode.edges {
 			newWeight := curNode.state + edge.weight
@@ -33,9 +38,10 @@ func (g *Graph) DijkstraSearch(start Node) []Path {
 				return nil
 			}
 			v := edge.end
-			if nodes.heapContains(v) && newWeight < v.state {
+			if nodes.contains(v) && newWeight < v.state {
 				v.parent = curNode
-				nodes.update(v.data, newWeight)
+				v.state = newWeight
+				nodes.decrease(v)
 			}
 		}
 
@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Dijkstra example on page 659 of CLRS ed 3
+func setupDijkstra() (*Graph, map[string]Node) {
+	g := New(Directed)
+	nodes := make(map[string]Node, 0)
+	for _, name := range []string{"s", "t", "x", "y", "z"} {
+		nodes[name] = g.MakeNode()
+		*nodes[name].Value = name
+	}
+	g.MakeEdgeWeight(nodes["s"], nodes["t"], 10)
+	g.MakeEdgeWeight(nodes["s"], nodes["y"], 5)
+	g.MakeEdgeWeight(nodes["t"], nodes["x"], 1)
+	g.MakeEdgeWeight(nodes["t"], nodes["y"], 2)
+	g.MakeEdgeWeight(nodes["y"], nodes["t"], 3)
+	g.MakeEdgeWeight(nodes["y"], nodes["x"], 9)
+	g.MakeEdgeWeight(nodes["y"], nodes["z"], 2)
+	g.MakeEdgeWeight(nodes["x"], nodes["z"], 4)
+	g.MakeEdgeWeight(nodes["z"], nodes["s"], 7)
+	g.MakeEdgeWeight(nodes["z"], nodes["x"], 6)
+	return g, nodes
+}
+
+func TestDijkstraSearch(t *testing.T) {
+	for _, kind := range []HeapKind{BinaryHeap, FibonacciHeap, QuaternaryHeap, RadixHeap} {
+		testDijkstraSearch(t, kind)
+	}
+}
+
+func testDijkstraSearch(t *testing.T, kind HeapKind) {
+	g, nodes := setupDijkstra()
+	paths := g.DijkstraSearchHeap(nodes["s"], kind)
+	want := map[string]struct {
+		weight int
+		path   string
+	}{
+		"s": {0, ""},
+		"t": {8, "syt"},
+		"x": {9, "sytx"},
+		"y": {5, "sy"},
+		"z": {7, "syz"},
+	}
+	for name, node := range nodes {
+		path := paths[node.node.index]
+		if path.Weight != want[name].weight {
+			t.Errorf("heap %v: path to %v has weight %v; want %v", kind, name, path.Weight, want[name].weight)
+		}
+		got := ""
+		for i, edge := range path.Path {
+			if i == 0 {
+				got += (*edge.Start.Value).(string)
+			}
+			got += (*edge.End.Value).(string)
+		}
+		if got != want[name].path {
+			t.Errorf("heap %v: path to %v is %v; want %v", kind, name, got, want[name].path)
+		}
+	}
+}
+
+// a random sparse graph with small positive weights
+func setupRandomSearch(n, degree int) *Graph {
+	return setupRandomGraph(Directed, n, degree)
+}
+
+func setupRandomGraph(kind GraphType, n, degree int) *Graph {
+	r := rand.New(rand.NewSource(1))
+	g := New(kind)
+	nodes := make([]Node, n)
+	for i := range nodes {
+		nodes[i] = g.MakeNode()
+	}
+	for i := range nodes {
+		for j := 0; j < degree; j++ {
+			g.MakeEdgeWeight(nodes[i], nodes[r.Intn(n)], r.Intn(100)+1)
+		}
+	}
+	return g
+}
+
+func TestDijkstraSearchHeapsAgree(t *testing.T) {
+	g := setupRandomSearch(500, 4)
+	start := g.nodes[0].container
+	binary := g.DijkstraSearchHeap(start, BinaryHeap)
+	for _, kind := range []HeapKind{FibonacciHeap, QuaternaryHeap, RadixHeap} {
+		paths := g.DijkstraSearchHeap(start, kind)
+		for i := range binary {
+			if binary[i].Weight != paths[i].Weight {
+				t.Errorf("node %v: binary heap weight %v != heap %v weight %v", i, binary[i].Weight, kind, paths[i].Weight)
+			}
+		}
+	}
+}
+
+func benchmarkDijkstraSearch(b *testing.B, kind HeapKind) {
+	g := setupRandomSearch(10000, 8)
+	start := g.nodes[0].container
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.DijkstraSearchHeap(start, kind)
+	}
+}
+
+func BenchmarkDijkstraSearch(b *testing.B)           { benchmarkDijkstraSearch(b, BinaryHeap) }
+func BenchmarkDijkstraSearchFibonacci(b *testing.B)  { benchmarkDijkstraSearch(b, FibonacciHeap) }
+func BenchmarkDijkstraSearchQuaternary(b *testing.B) { benchmarkDijkstraSearch(b, QuaternaryHeap) }
+func BenchmarkDijkstraSearchRadix(b *testing.B)      { benchmarkDijkstraSearch(b, RadixHeap) }
+
+// Prim's algorithm runs on the same queue as DijkstraSearch.
+func BenchmarkMinimumSpanningTree(b *testing.B) {
+	g := setupRandomGraph(Undirected, 10000, 4)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.MinimumSpanningTree()
+	}
+}
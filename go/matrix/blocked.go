@@ -0,0 +1,88 @@
+package matrix
+
+import (
+	"runtime"
+	"sync"
+)
+
+// DefaultBlock is the tile size BlockedMultiply uses when it is given a block of 0.
+const DefaultBlock = 64
+
+// Returns a new rows by cols matrix of zeros.
+func zeros(rows, cols int) [][]int {
+	C := make([][]int, rows)
+	backing := make([]int, rows*cols)
+	for i := range C {
+		C[i] = backing[i*cols:][:cols:cols]
+	}
+	return C
+}
+
+// Adds rows [from, to) of A * B into C, with the i-k-j loop order that
+// walks rows of B and C in order instead of striding down columns.
+func multiplyRows(A, B, C [][]int, from, to int) {
+	for i := from; i < to; i++ {
+		c := C[i]
+		for k, aik := range A[i] {
+			for j, bkj := range B[k] {
+				c[j] += aik * bkj
+			}
+		}
+	}
+}
+
+// BlockedMultiply multiplies A and B tile by tile, block rows and columns
+// at a time, so the tiles of A, B and C being worked on stay in cache
+// together. It does the same O(n^3) work as BasicMultiply but is much
+// faster once the matrices outgrow the cache. A block of 0 uses DefaultBlock.
+func BlockedMultiply(A, B [][]int, block int) ([][]int, error) {
+	if err := testBounds(A, B); err != nil {
+		return nil, err
+	}
+	if block <= 0 {
+		block = DefaultBlock
+	}
+	n, m, p := len(A), len(B), len(B[0])
+	C := zeros(n, p)
+	for ii := 0; ii < n; ii += block {
+		for kk := 0; kk < m; kk += block {
+			for jj := 0; jj < p; jj += block {
+				for i := ii; i < min(ii+block, n); i++ {
+					c := C[i][jj:min(jj+block, p)]
+					for k := kk; k < min(kk+block, m); k++ {
+						aik := A[i][k]
+						b := B[k][jj : jj+len(c)]
+						for j := range c {
+							c[j] += aik * b[j]
+						}
+					}
+				}
+			}
+		}
+	}
+	return C, nil
+}
+
+// ParallelMultiply multiplies A and B on workers goroutines, each
+// computing a band of rows of the result. A workers of 0 uses GOMAXPROCS.
+func ParallelMultiply(A, B [][]int, workers int) ([][]int, error) {
+	if err := testBounds(A, B); err != nil {
+		return nil, err
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	n := len(A)
+	workers = min(workers, n)
+	C := zeros(n, len(B[0]))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(from, to int) {
+			defer wg.Done()
+			multiplyRows(A, B, C, from, to)
+		}(w*n/workers, (w+1)*n/workers)
+	}
+	wg.Wait()
+	return C, nil
+}
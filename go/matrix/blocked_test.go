@@ -0,0 +1,109 @@
+package matrix
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func randomMatrix(r *rand.Rand, rows, cols int) [][]int {
+	M := make([][]int, rows)
+	for i := range M {
+		M[i] = make([]int, cols)
+		for j := range M[i] {
+			M[i][j] = r.Intn(201) - 100
+		}
+	}
+	return M
+}
+
+// Checks multiply against every case in tests and against BasicMultiply
+// on random matrices of many shapes.
+func testAgainstBasic(t *testing.T, name string, multiply func(A, B [][]int) ([][]int, error)) {
+	check := func(label string, got, want [][]int) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("%s(%v) has %d rows; want %d", name, label, len(got), len(want))
+		}
+		for i := range want {
+			if len(got[i]) != len(want[i]) {
+				t.Fatalf("%s(%v) row %d has %d columns; want %d", name, label, i, len(got[i]), len(want[i]))
+			}
+			for j := range want[i] {
+				if got[i][j] != want[i][j] {
+					t.Fatalf("%s(%v) [%d][%d] = %d; want %d", name, label, i, j, got[i][j], want[i][j])
+				}
+			}
+		}
+	}
+	for _, test := range tests {
+		result, err := multiply(test.inA, test.inB)
+		if test.want.e != nil {
+			if err == nil || err.Error() != test.want.e.Error() || result != nil {
+				t.Errorf("%s(%v, %v) = %v, %v; want error %v", name, test.inA, test.inB, result, err, test.want.e)
+			}
+		} else if err != nil {
+			t.Errorf("%s(%v, %v): %v", name, test.inA, test.inB, err)
+		} else {
+			check(fmt.Sprint(test.inA, test.inB), result, test.want.r)
+		}
+	}
+	r := rand.New(rand.NewSource(49))
+	for _, shape := range [][3]int{{1, 1, 1}, {2, 3, 4}, {7, 5, 3}, {64, 64, 64}, {65, 33, 100}, {130, 129, 131}} {
+		A := randomMatrix(r, shape[0], shape[1])
+		B := randomMatrix(r, shape[1], shape[2])
+		want, _ := BasicMultiply(A, B)
+		got, err := multiply(A, B)
+		if err != nil {
+			t.Fatalf("%s(%v): %v", name, shape, err)
+		}
+		check(fmt.Sprint(shape), got, want)
+	}
+}
+
+func TestBlockedMultiply(t *testing.T) {
+	for _, block := range []int{0, 1, 7, 32} {
+		testAgainstBasic(t, "BlockedMultiply", func(A, B [][]int) ([][]int, error) {
+			return BlockedMultiply(A, B, block)
+		})
+	}
+}
+
+func TestParallelMultiply(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 200} {
+		testAgainstBasic(t, "ParallelMultiply", func(A, B [][]int) ([][]int, error) {
+			return ParallelMultiply(A, B, workers)
+		})
+	}
+}
+
+func benchmarkMultiply(b *testing.B, n int, multiply func(A, B [][]int) ([][]int, error)) {
+	r := rand.New(rand.NewSource(49))
+	A, B := randomMatrix(r, n, n), randomMatrix(r, n, n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		multiply(A, B)
+	}
+}
+
+func blocked(A, B [][]int) ([][]int, error)  { return BlockedMultiply(A, B, 0) }
+func parallel(A, B [][]int) ([][]int, error) { return ParallelMultiply(A, B, 0) }
+func strassen0(A, B [][]int) ([][]int, error) {
+	return StrassenMultiply(A, B, 0)
+}
+
+func BenchmarkBasicMultiply64(b *testing.B)      { benchmarkMultiply(b, 64, BasicMultiply) }
+func BenchmarkRecursiveMultiply64(b *testing.B)  { benchmarkMultiply(b, 64, RecursiveMultiply) }
+func BenchmarkBlockedMultiply64(b *testing.B)    { benchmarkMultiply(b, 64, blocked) }
+func BenchmarkParallelMultiply64(b *testing.B)   { benchmarkMultiply(b, 64, parallel) }
+func BenchmarkStrassenMultiply64(b *testing.B)   { benchmarkMultiply(b, 64, strassen0) }
+func BenchmarkBasicMultiply256(b *testing.B)     { benchmarkMultiply(b, 256, BasicMultiply) }
+func BenchmarkBlockedMultiply256(b *testing.B)   { benchmarkMultiply(b, 256, blocked) }
+func BenchmarkParallelMultiply256(b *testing.B)  { benchmarkMultiply(b, 256, parallel) }
+func BenchmarkStrassenMultiply256(b *testing.B)  { benchmarkMultiply(b, 256, strassen0) }
+func BenchmarkBasicMultiply512(b *testing.B)     { benchmarkMultiply(b, 512, BasicMultiply) }
+func BenchmarkBlockedMultiply512(b *testing.B)   { benchmarkMultiply(b, 512, blocked) }
+func BenchmarkParallelMultiply512(b *testing.B)  { benchmarkMultiply(b, 512, parallel) }
+func BenchmarkStrassenMultiply512(b *testing.B)  { benchmarkMultiply(b, 512, strassen0) }
+func BenchmarkStrassenMultiply1000(b *testing.B) { benchmarkMultiply(b, 1000, strassen0) }
+func BenchmarkBlockedMultiply1000(b *testing.B)  { benchmarkMultiply(b, 1000, blocked) }
@@ -0,0 +1,215 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// Number is the element type of a Matrix.
+type Number interface {
+	int | float64 | complex128
+}
+
+// Matrix is a rows by cols matrix stored contiguously in row-major order.
+// A Matrix can be a view into a larger one, sharing its storage: row i
+// of a view starts stride elements after row i-1.
+//
+// Operations that produce a new matrix never modify their operands,
+// and always return a matrix with storage of its own.
+type Matrix[T Number] struct {
+	data               []T
+	rows, cols, stride int
+}
+
+// Zeros returns a new rows by cols matrix of zeros.
+// It panics if rows or cols is negative.
+func Zeros[T Number](rows, cols int) *Matrix[T] {
+	if rows < 0 || cols < 0 {
+		panic("Zeros: negative dimension")
+	}
+	return &Matrix[T]{data: make([]T, rows*cols), rows: rows, cols: cols, stride: cols}
+}
+
+// Identity returns a new n by n identity matrix.
+func Identity[T Number](n int) *Matrix[T] {
+	m := Zeros[T](n, n)
+	for i := 0; i < n; i++ {
+		m.data[i*n+i] = 1
+	}
+	return m
+}
+
+// FromRows returns a new matrix holding a copy of rows.
+// It returns an error if the rows are not all the same length.
+func FromRows[T Number](rows [][]T) (*Matrix[T], error) {
+	if len(rows) == 0 {
+		return Zeros[T](0, 0), nil
+	}
+	m := Zeros[T](len(rows), len(rows[0]))
+	for i, row := range rows {
+		if len(row) != m.cols {
+			return nil, fmt.Errorf("FromRows: row %d has %d columns; want %d", i, len(row), m.cols)
+		}
+		copy(m.row(i), row)
+	}
+	return m, nil
+}
+
+// Returns row i as a slice of the matrix's storage.
+func (m *Matrix[T]) row(i int) []T {
+	return m.data[i*m.stride:][:m.cols:m.cols]
+}
+
+// Dims returns the number of rows and columns in m.
+func (m *Matrix[T]) Dims() (rows, cols int) {
+	return m.rows, m.cols
+}
+
+// At returns the element at row i, column j. It panics if i or j is out of range.
+func (m *Matrix[T]) At(i, j int) T {
+	m.check(i, j)
+	return m.data[i*m.stride+j]
+}
+
+// Set sets the element at row i, column j. It panics if i or j is out of range.
+func (m *Matrix[T]) Set(i, j int, v T) {
+	m.check(i, j)
+	m.data[i*m.stride+j] = v
+}
+
+func (m *Matrix[T]) check(i, j int) {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("matrix: index (%d, %d) out of range for %d by %d matrix", i, j, m.rows, m.cols))
+	}
+}
+
+// Rows returns a copy of m as a slice of rows, for use with
+// BasicMultiply and the other functions on [][]int.
+func (m *Matrix[T]) Rows() [][]T {
+	rows := make([][]T, m.rows)
+	for i := range rows {
+		rows[i] = append([]T(nil), m.row(i)...)
+	}
+	return rows
+}
+
+// View returns the rows by cols block of m whose top left element is at
+// row i, column j. The view shares m's storage, so setting an element of
+// one sets it in the other. It returns an error if the block does not fit in m.
+func (m *Matrix[T]) View(i, j, rows, cols int) (*Matrix[T], error) {
+	if i < 0 || j < 0 || rows < 0 || cols < 0 || i+rows > m.rows || j+cols > m.cols {
+		return nil, errors.New("View: block out of range")
+	}
+	if rows == 0 || cols == 0 { // no storage to share, and none for row to index
+		return Zeros[T](rows, cols), nil
+	}
+	return &Matrix[T]{data: m.data[i*m.stride+j:], rows: rows, cols: cols, stride: m.stride}, nil
+}
+
+func (m *Matrix[T]) sameDims(b *Matrix[T]) bool {
+	return m.rows == b.rows && m.cols == b.cols
+}
+
+// Add returns m + b. It returns an error if their dimensions differ.
+func (m *Matrix[T]) Add(b *Matrix[T]) (*Matrix[T], error) {
+	if !m.sameDims(b) {
+		return nil, errors.New("Dimension mismatch")
+	}
+	c := Zeros[T](m.rows, m.cols)
+	for i := 0; i < m.rows; i++ {
+		cr, mr, br := c.row(i), m.row(i), b.row(i)
+		for j := range cr {
+			cr[j] = mr[j] + br[j]
+		}
+	}
+	return c, nil
+}
+
+// Sub returns m - b. It returns an error if their dimensions differ.
+func (m *Matrix[T]) Sub(b *Matrix[T]) (*Matrix[T], error) {
+	if !m.sameDims(b) {
+		return nil, errors.New("Dimension mismatch")
+	}
+	c := Zeros[T](m.rows, m.cols)
+	for i := 0; i < m.rows; i++ {
+		cr, mr, br := c.row(i), m.row(i), b.row(i)
+		for j := range cr {
+			cr[j] = mr[j] - br[j]
+		}
+	}
+	return c, nil
+}
+
+// Scale returns k times m.
+func (m *Matrix[T]) Scale(k T) *Matrix[T] {
+	c := Zeros[T](m.rows, m.cols)
+	for i := 0; i < m.rows; i++ {
+		cr, mr := c.row(i), m.row(i)
+		for j := range cr {
+			cr[j] = k * mr[j]
+		}
+	}
+	return c
+}
+
+// Transpose returns the transpose of m.
+func (m *Matrix[T]) Transpose() *Matrix[T] {
+	c := Zeros[T](m.cols, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j, v := range m.row(i) {
+			c.data[j*c.stride+i] = v
+		}
+	}
+	return c
+}
+
+// Mul returns m * b. It returns an error if m does not have as many
+// columns as b has rows.
+func (m *Matrix[T]) Mul(b *Matrix[T]) (*Matrix[T], error) {
+	if m.cols != b.rows {
+		return nil, errors.New("Dimension mismatch")
+	}
+	c := Zeros[T](m.rows, b.cols)
+	for i := 0; i < m.rows; i++ {
+		cr := c.row(i)
+		for k, mik := range m.row(i) {
+			for j, bkj := range b.row(k) {
+				cr[j] += mik * bkj
+			}
+		}
+	}
+	return c, nil
+}
+
+// Returns |a - b|.
+func distance[T Number](a, b T) float64 {
+	switch d := any(a - b).(type) {
+	case int:
+		return math.Abs(float64(d))
+	case float64:
+		return math.Abs(d)
+	case complex128:
+		return cmplx.Abs(d)
+	}
+	panic("unreachable")
+}
+
+// Equal returns whether m and b have the same dimensions and every pair
+// of their elements is within tol of each other. Use a tol of 0 for exact
+// equality, and a small positive tol to allow for floating point rounding.
+func (m *Matrix[T]) Equal(b *Matrix[T], tol float64) bool {
+	if !m.sameDims(b) {
+		return false
+	}
+	for i := 0; i < m.rows; i++ {
+		mr, br := m.row(i), b.row(i)
+		for j := range mr {
+			if !(distance(mr[j], br[j]) <= tol) { // false for NaN
+				return false
+			}
+		}
+	}
+	return true
+}
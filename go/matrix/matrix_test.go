@@ -0,0 +1,152 @@
+package matrix
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func mustFromRows[T Number](t *testing.T, rows [][]T) *Matrix[T] {
+	t.Helper()
+	m, err := FromRows(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestFromRows(t *testing.T) {
+	if _, err := FromRows([][]int{{1, 2}, {3}}); err == nil {
+		t.Errorf("FromRows accepted ragged rows")
+	}
+	m, err := FromRows[int](nil)
+	if r, c := m.Dims(); err != nil || r != 0 || c != 0 {
+		t.Errorf("FromRows(nil) = %d by %d, %v; want 0 by 0", r, c, err)
+	}
+	in := [][]float64{{1, 2, 3}, {4, 5, 6}}
+	f := mustFromRows(t, in)
+	in[0][0] = 9 // FromRows copies
+	if r, c := f.Dims(); r != 2 || c != 3 || f.At(0, 0) != 1 || f.At(1, 2) != 6 {
+		t.Errorf("FromRows = %v", f.Rows())
+	}
+}
+
+func TestIdentityMul(t *testing.T) {
+	r := rand.New(rand.NewSource(50))
+	A := randomMatrix(r, 5, 7)
+	B := randomMatrix(r, 7, 3)
+	a, b := mustFromRows(t, A), mustFromRows(t, B)
+	want, _ := BasicMultiply(A, B)
+	got, err := a.Mul(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(mustFromRows(t, want), 0) {
+		t.Errorf("Mul = %v; want %v", got.Rows(), want)
+	}
+	if id, _ := Identity[int](5).Mul(a); !id.Equal(a, 0) {
+		t.Errorf("I * A != A")
+	}
+	if _, err := b.Mul(b); err == nil {
+		t.Errorf("Mul of mismatched dimensions succeeded")
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a := mustFromRows(t, [][]complex128{{1 + 1i, 2}, {3, 4i}})
+	b := mustFromRows(t, [][]complex128{{1, 1}, {1i, 1}})
+	sum, err := a.Add(b)
+	if err != nil || !sum.Equal(mustFromRows(t, [][]complex128{{2 + 1i, 3}, {3 + 1i, 1 + 4i}}), 0) {
+		t.Errorf("Add = %v, %v", sum.Rows(), err)
+	}
+	diff, err := sum.Sub(b)
+	if err != nil || !diff.Equal(a, 0) {
+		t.Errorf("Sub = %v, %v", diff.Rows(), err)
+	}
+	if s := a.Scale(2i); s.At(0, 0) != -2+2i || s.At(1, 1) != -8 {
+		t.Errorf("Scale = %v", s.Rows())
+	}
+	if _, err := a.Add(Zeros[complex128](2, 3)); err == nil {
+		t.Errorf("Add of mismatched dimensions succeeded")
+	}
+	if _, err := a.Sub(Zeros[complex128](3, 2)); err == nil {
+		t.Errorf("Sub of mismatched dimensions succeeded")
+	}
+	tr := mustFromRows(t, [][]int{{1, 2, 3}, {4, 5, 6}}).Transpose()
+	if !tr.Equal(mustFromRows(t, [][]int{{1, 4}, {2, 5}, {3, 6}}), 0) {
+		t.Errorf("Transpose = %v", tr.Rows())
+	}
+}
+
+func TestView(t *testing.T) {
+	m := mustFromRows(t, [][]int{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}})
+	v, err := m.View(1, 1, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Equal(mustFromRows(t, [][]int{{6, 7}, {10, 11}}), 0) {
+		t.Errorf("View = %v", v.Rows())
+	}
+	v.Set(0, 0, 60)
+	if m.At(1, 1) != 60 {
+		t.Errorf("View does not share storage with its matrix")
+	}
+	sq, _ := v.Mul(Identity[int](2)) // operations work on views
+	if !sq.Equal(v, 0) {
+		t.Errorf("view * I = %v; want %v", sq.Rows(), v.Rows())
+	}
+	if tr := v.Transpose(); tr.At(0, 1) != 10 {
+		t.Errorf("Transpose of view = %v", tr.Rows())
+	}
+	for _, bad := range [][4]int{{-1, 0, 1, 1}, {2, 0, 2, 1}, {0, 3, 1, 2}, {0, 0, -1, 1}} {
+		if _, err := m.View(bad[0], bad[1], bad[2], bad[3]); err == nil {
+			t.Errorf("View%v succeeded", bad)
+		}
+	}
+	for _, dims := range [][4]int{{3, 4, 0, 0}, {0, 0, 2, 0}, {0, 0, 0, 3}} {
+		empty, err := m.View(dims[0], dims[1], dims[2], dims[3])
+		if err != nil {
+			t.Errorf("empty View%v: %v", dims, err)
+			continue
+		}
+		if r, c := empty.Dims(); r != dims[2] || c != dims[3] {
+			t.Errorf("empty View%v is %d by %d", dims, r, c)
+		}
+		// every operation must work on an empty view that still has rows or columns
+		if rows := empty.Rows(); len(rows) != dims[2] {
+			t.Errorf("empty View%v has %d rows", dims, len(rows))
+		}
+		if sum, err := empty.Add(empty); err != nil || !sum.Equal(empty, 0) {
+			t.Errorf("empty View%v + itself = %v, %v", dims, sum, err)
+		}
+		if diff, err := empty.Sub(empty); err != nil || !diff.Equal(Zeros[int](dims[2], dims[3]), 0) {
+			t.Errorf("empty View%v - itself = %v, %v", dims, diff, err)
+		}
+		if tr := empty.Transpose(); !tr.Scale(2).Equal(Zeros[int](dims[3], dims[2]), 0) {
+			t.Errorf("empty View%v transposed = %v", dims, tr.Rows())
+		}
+	}
+}
+
+func TestEqualTolerance(t *testing.T) {
+	x, y := 0.1, 0.2 // variables, so the sum is not computed exactly at compile time
+	a := mustFromRows(t, [][]float64{{x + y, 1}})
+	b := mustFromRows(t, [][]float64{{0.3, 1}})
+	if a.Equal(b, 0) {
+		t.Errorf("0.1 + 0.2 exactly equal to 0.3")
+	}
+	if !a.Equal(b, 1e-9) {
+		t.Errorf("0.1 + 0.2 not within 1e-9 of 0.3")
+	}
+	if a.Equal(Zeros[float64](2, 1), 1) {
+		t.Errorf("Equal of mismatched dimensions")
+	}
+}
+
+func TestAtPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("At out of range did not panic")
+		}
+	}()
+	Zeros[int](2, 2).At(2, 0)
+}
@@ -115,14 +115,9 @@ func RecursiveMultiply(A, B [][]int) ([][]int, error) {
 	// a mix of ideas in getting this to work. Ideally, I would only use one C matrix and do operations based off
 	// of relative indices. But I don't have that yet. I both duplicate smaller boxes many times and pass around C.
 	// So it's not smart. But it works!
-	// So, the TODO list is:
-	//  1) Take out the duplications on C boxes
-	//  2) Take out the padding for the multipication and work magically with the numbers
-	//  3) Implement Strassen's algorithm
-	//
-	// Yet again, I'm probably not going to do any of these unless I get *really* bored some day because I find this algorithm
-	// so boring and somewhat needlessly complex to implement (with slices, though I could switch to arrays and maybe it'd be
-	// easier that way. Hmm...).
+	// The old TODO list (no duplicated C boxes, less padding, Strassen's algorithm) is done in strassen.go:
+	// StrassenMultiply works on views into one backing array, pads only enough to halve evenly down to its cutoff,
+	// and does seven recursive multiplications instead of eight. This stays as the plain eight-multiplication version.
 	err := testBounds(A, B)
 	if err != nil {
 		return nil, err
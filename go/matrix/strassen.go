@@ -0,0 +1,161 @@
+package matrix
+
+// DefaultStrassenCutoff is the size at or below which StrassenMultiply
+// multiplies blocks directly when it is given a cutoff of 0.
+const DefaultStrassenCutoff = 64
+
+// A square view into a row-major slice: element (i, j) is a[i*stride+j].
+type square struct {
+	a         []int
+	stride, n int
+}
+
+func newSquare(n int) square {
+	return square{make([]int, n*n), n, n}
+}
+
+// Returns quadrant (r, c) of s, where r and c are 0 or 1. s.n must be even.
+func (s square) quad(r, c int) square {
+	h := s.n / 2
+	return square{s.a[r*h*s.stride+c*h:], s.stride, h}
+}
+
+// dst = x + y
+func addSquare(dst, x, y square) {
+	for i := 0; i < dst.n; i++ {
+		d, xr, yr := dst.a[i*dst.stride:][:dst.n], x.a[i*x.stride:][:dst.n], y.a[i*y.stride:][:dst.n]
+		for j := range d {
+			d[j] = xr[j] + yr[j]
+		}
+	}
+}
+
+// dst = x - y
+func subSquare(dst, x, y square) {
+	for i := 0; i < dst.n; i++ {
+		d, xr, yr := dst.a[i*dst.stride:][:dst.n], x.a[i*x.stride:][:dst.n], y.a[i*y.stride:][:dst.n]
+		for j := range d {
+			d[j] = xr[j] - yr[j]
+		}
+	}
+}
+
+// dst = x * y, with the i-k-j loop order that walks every row in order.
+func mulSquare(dst, x, y square) {
+	n := dst.n
+	for i := 0; i < n; i++ {
+		d := dst.a[i*dst.stride:][:n]
+		clear(d)
+		for k := 0; k < n; k++ {
+			xik := x.a[i*x.stride+k]
+			yr := y.a[k*y.stride:][:n]
+			for j := range d {
+				d[j] += xik * yr[j]
+			}
+		}
+	}
+}
+
+// Returns how much scratch space strassen needs to multiply n by n
+// squares: nine half size squares for every level it recurses through.
+func strassenScratch(n, cutoff int) int {
+	size := 0
+	for n > cutoff && n%2 == 0 {
+		n /= 2
+		size += 9 * n * n
+	}
+	return size
+}
+
+// dst = x * y by Strassen's algorithm: seven half size products instead
+// of eight, at the cost of eighteen half size additions. scratch must
+// hold at least strassenScratch(x.n, cutoff) ints. Each level takes its
+// nine temporaries from the front and hands the rest down, which the
+// seven products can share, since they run one after another.
+func strassen(dst, x, y square, cutoff int, scratch []int) {
+	if x.n <= cutoff || x.n%2 == 1 {
+		mulSquare(dst, x, y)
+		return
+	}
+	h := x.n / 2
+	a11, a12, a21, a22 := x.quad(0, 0), x.quad(0, 1), x.quad(1, 0), x.quad(1, 1)
+	b11, b12, b21, b22 := y.quad(0, 0), y.quad(0, 1), y.quad(1, 0), y.quad(1, 1)
+	c11, c12, c21, c22 := dst.quad(0, 0), dst.quad(0, 1), dst.quad(1, 0), dst.quad(1, 1)
+	var tmp [9]square
+	for i := range tmp {
+		tmp[i] = square{scratch[i*h*h:][:h*h], h, h}
+	}
+	scratch = scratch[9*h*h:]
+	s, t, m := tmp[0], tmp[1], tmp[2:]
+	addSquare(s, a11, a22)
+	addSquare(t, b11, b22)
+	strassen(m[0], s, t, cutoff, scratch) // (A11 + A22)(B11 + B22)
+	addSquare(s, a21, a22)
+	strassen(m[1], s, b11, cutoff, scratch) // (A21 + A22)B11
+	subSquare(t, b12, b22)
+	strassen(m[2], a11, t, cutoff, scratch) // A11(B12 - B22)
+	subSquare(t, b21, b11)
+	strassen(m[3], a22, t, cutoff, scratch) // A22(B21 - B11)
+	addSquare(s, a11, a12)
+	strassen(m[4], s, b22, cutoff, scratch) // (A11 + A12)B22
+	subSquare(s, a21, a11)
+	addSquare(t, b11, b12)
+	strassen(m[5], s, t, cutoff, scratch) // (A21 - A11)(B11 + B12)
+	subSquare(s, a12, a22)
+	addSquare(t, b21, b22)
+	strassen(m[6], s, t, cutoff, scratch) // (A12 - A22)(B21 + B22)
+
+	addSquare(c11, m[0], m[3]) // C11 = M1 + M4 - M5 + M7
+	subSquare(c11, c11, m[4])
+	addSquare(c11, c11, m[6])
+	addSquare(c12, m[2], m[4]) // C12 = M3 + M5
+	addSquare(c21, m[1], m[3]) // C21 = M2 + M4
+	subSquare(c22, m[0], m[1]) // C22 = M1 - M2 + M3 + M6
+	addSquare(c22, c22, m[2])
+	addSquare(c22, c22, m[5])
+}
+
+// Returns the size to pad an n by n matrix to so that halving it until
+// it is at most cutoff never leaves an odd size. This pads by less than
+// the number of halvings, rather than up to a power of two.
+func strassenSize(n, cutoff int) int {
+	halvings := 0
+	for leaf := n; leaf > cutoff; leaf = (leaf + 1) / 2 {
+		halvings++
+	}
+	leaf := (n + 1<<halvings - 1) >> halvings // ceil(n / 2^halvings)
+	return leaf << halvings
+}
+
+// Copies M into the top left of a new m by m square.
+func toSquare(M [][]int, m int) square {
+	s := newSquare(m)
+	for i, row := range M {
+		copy(s.a[i*m:], row)
+	}
+	return s
+}
+
+// StrassenMultiply multiplies A and B with Strassen's algorithm, which
+// takes O(n^lg 7) ~ O(n^2.81) time rather than O(n^3). The matrices are
+// padded with zeros to a square of a size that halves evenly down to
+// blocks of at most cutoff, and those blocks are multiplied directly,
+// since Strassen's extra additions cost more than they save on small
+// blocks. A cutoff of 0 uses DefaultStrassenCutoff.
+func StrassenMultiply(A, B [][]int, cutoff int) ([][]int, error) {
+	if err := testBounds(A, B); err != nil {
+		return nil, err
+	}
+	if cutoff <= 0 {
+		cutoff = DefaultStrassenCutoff
+	}
+	n := max(len(A), len(B), len(B[0]))
+	m := strassenSize(n, cutoff)
+	C := newSquare(m)
+	strassen(C, toSquare(A, m), toSquare(B, m), cutoff, make([]int, strassenScratch(m, cutoff)))
+	result := make([][]int, len(A))
+	for i := range result {
+		result[i] = C.a[i*m:][:len(B[0]):len(B[0])]
+	}
+	return result, nil
+}
@@ -0,0 +1,31 @@
+package matrix
+
+import (
+	"testing"
+)
+
+func TestStrassenMultiply(t *testing.T) {
+	for _, cutoff := range []int{0, 1, 2, 5, 16} {
+		testAgainstBasic(t, "StrassenMultiply", func(A, B [][]int) ([][]int, error) {
+			return StrassenMultiply(A, B, cutoff)
+		})
+	}
+}
+
+func TestStrassenSize(t *testing.T) {
+	tests := []struct {
+		n, cutoff, want int
+	}{
+		{1, 64, 1},
+		{64, 64, 64},
+		{100, 64, 100},
+		{129, 64, 132},
+		{1000, 64, 1008},
+		{5, 1, 8},
+	}
+	for _, test := range tests {
+		if got := strassenSize(test.n, test.cutoff); got != test.want {
+			t.Errorf("strassenSize(%d, %d) = %d; want %d", test.n, test.cutoff, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package dupsort
+
+// Runs shorter than this are insertion sorted before merging.
+const insertionRun = 16
+
+// sequence is the element access the merge sorts below need.
+// A DupSortable is a sequence[interface{}], and slice adapts a []T
+// so that the same code can sort it without boxing its elements.
+type sequence[T any] interface {
+	At(i int) T
+	Set(i int, v T)
+}
+
+type slice[T any] []T
+
+func (s slice[T]) At(i int) T     { return s[i] }
+func (s slice[T]) Set(i int, v T) { s[i] = v }
+
+// Insertion sorts [a, b) of me in place.
+func insertionSort[T any, S sequence[T]](me S, a, b int, less func(a, b T) bool) {
+	for j := a + 1; j < b; j++ {
+		v := me.At(j)
+		i := j
+		for ; i > a && less(v, me.At(i-1)); i-- {
+			me.Set(i, me.At(i-1))
+		}
+		me.Set(i, v)
+	}
+}
+
+// Copies n elements from src starting at from to dst starting at to.
+// src and dst may be the same collection with overlapping ranges.
+func copyRange[T any, S sequence[T]](src S, from int, dst S, to, n int) {
+	if to > from {
+		for i := n - 1; i >= 0; i-- {
+			dst.Set(to+i, src.At(from+i))
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		dst.Set(to+i, src.At(from+i))
+	}
+}
+
+// Merges the sorted runs [a, m) and [m, b) of src into [a, b) of dst.
+// Equal elements are taken from the left run first, keeping the merge stable.
+func merge[T any, S sequence[T]](src, dst S, a, m, b int, less func(a, b T) bool) {
+	i, j := a, m
+	for k := a; k < b; k++ {
+		if i < m && (j >= b || !less(src.At(j), src.At(i))) {
+			dst.Set(k, src.At(i))
+			i++
+		} else {
+			dst.Set(k, src.At(j))
+			j++
+		}
+	}
+}
+
+// The bottom up merge sort shared by BottomUpMergeSort and MergeSortFunc.
+// Sorts the n elements of me, calling scratch once for a second
+// collection of length n if there is anything to merge.
+func bottomUp[T any, S sequence[T]](me S, n int, less func(a, b T) bool, scratch func() S) {
+	for a := 0; a < n; a += insertionRun {
+		insertionSort(me, a, min(a+insertionRun, n), less)
+	}
+	if n <= insertionRun {
+		return
+	}
+	src, dst := me, scratch()
+	inMe := true // whether src is me
+	for width := insertionRun; width < n; width *= 2 {
+		for a := 0; a < n; a += 2 * width {
+			merge(src, dst, a, min(a+width, n), min(a+2*width, n), less)
+		}
+		src, dst = dst, src
+		inMe = !inMe
+	}
+	if !inMe {
+		copyRange(src, 0, me, 0, n)
+	}
+}
+
+// Reverses [a, b) of me.
+func reverse(me DupSortable, a, b int) {
+	for b--; a < b; a, b = a+1, b-1 {
+		va, vb := me.At(a), me.At(b)
+		me.Set(a, vb)
+		me.Set(b, va)
+	}
+}
+
+// Returns the end of the run starting at a. A strictly descending
+// run is reversed so that every run is ascending; strictness keeps
+// equal elements in order.
+func countRun(me DupSortable, a, n int) int {
+	b := a + 1
+	if b >= n {
+		return n
+	}
+	if me.Less(me.At(b), me.At(a)) {
+		for b++; b < n && me.Less(me.At(b), me.At(b-1)); b++ {
+		}
+		reverse(me, a, b)
+	} else {
+		for b++; b < n && !me.Less(me.At(b), me.At(b-1)); b++ {
+		}
+	}
+	return b
+}
+
+// Sorts me in place with a stable, bottom up merge sort. Small runs are
+// insertion sorted, then runs of doubling width are merged back and forth
+// between me and a single scratch collection from me.New. Unlike
+// MergeSort, which calls New for every element and every merge, this
+// calls New once. Runs in O(n * lg n) time.
+func BottomUpMergeSort(me DupSortable) {
+	n := me.Len()
+	bottomUp(me, n, me.Less, func() DupSortable { return me.New(n) })
+}
+
+// Sorts me in place with a stable, natural merge sort: the ascending and
+// strictly descending runs already in me are found and merged pairwise,
+// so already sorted input takes a single O(n) pass. Like
+// BottomUpMergeSort, it calls New once. Runs in O(n * lg r) time for
+// r runs.
+func NaturalMergeSort(me DupSortable) {
+	n := me.Len()
+	runs := []int{0} // the start of every run, followed by n
+	for i := 0; i < n; {
+		i = countRun(me, i, n)
+		runs = append(runs, i)
+	}
+	if len(runs) <= 2 {
+		return
+	}
+	src, dst := me, me.New(n)
+	inMe := true
+	for len(runs) > 2 {
+		merged := runs[:1] // overwrites runs behind the reads
+		k := 0
+		for ; k+2 < len(runs); k += 2 {
+			merge(src, dst, runs[k], runs[k+1], runs[k+2], me.Less)
+			merged = append(merged, runs[k+2])
+		}
+		if k+1 < len(runs) { // an odd run out
+			copyRange(src, runs[k], dst, runs[k], runs[k+1]-runs[k])
+			merged = append(merged, runs[k+1])
+		}
+		runs = merged
+		src, dst = dst, src
+		inMe = !inMe
+	}
+	if !inMe {
+		copyRange(src, 0, me, 0, n)
+	}
+}
@@ -0,0 +1,150 @@
+package dupsort
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// A key and the position it started at. Only keys are compared.
+type tagged struct {
+	key, tag int
+}
+
+type taggeds []tagged
+
+func (p taggeds) Len() int                   { return len(p) }
+func (p taggeds) Less(i, j interface{}) bool { return i.(tagged).key < j.(tagged).key }
+func (p taggeds) At(i int) interface{}       { return p[i] }
+func (p taggeds) Set(i int, v interface{})   { p[i] = v.(tagged) }
+func (p taggeds) New(i int) DupSortable      { return make(taggeds, i) }
+
+// Returns keys of length n in shapes that exercise run detection and galloping.
+func inputs(n int) map[string][]int {
+	r := rand.New(rand.NewSource(int64(n)))
+	in := map[string][]int{
+		"random":     make([]int, n),
+		"fewUnique":  make([]int, n),
+		"sorted":     make([]int, n),
+		"reversed":   make([]int, n),
+		"sortedRuns": make([]int, n),
+		"organPipe":  make([]int, n),
+		"nearSorted": make([]int, n),
+	}
+	for i := 0; i < n; i++ {
+		in["random"][i] = r.Intn(n + 1)
+		in["fewUnique"][i] = r.Intn(4)
+		in["sorted"][i] = i
+		in["reversed"][i] = n - i
+		in["sortedRuns"][i] = i % 100 * (1 + i/1000)
+		in["organPipe"][i] = min(i, n-i)
+		in["nearSorted"][i] = i
+	}
+	for i := 0; i < n/50; i++ {
+		a, b := r.Intn(n), r.Intn(n)
+		in["nearSorted"][a], in["nearSorted"][b] = in["nearSorted"][b], in["nearSorted"][a]
+	}
+	return in
+}
+
+// Checks that sort sorts every input stably, in place.
+func testStableSort(t *testing.T, name string, sort func(DupSortable)) {
+	for _, n := range []int{0, 1, 2, 15, 16, 17, 31, 33, 100, 1000, 5000} {
+		for input, keys := range inputs(n) {
+			s := make(taggeds, n)
+			for i, k := range keys {
+				s[i] = tagged{k, i}
+			}
+			sort(s)
+			for i := 1; i < n; i++ {
+				if s[i].key < s[i-1].key {
+					t.Fatalf("%s(%s, n=%d) not sorted at %d", name, input, n, i)
+				}
+				if s[i].key == s[i-1].key && s[i].tag < s[i-1].tag {
+					t.Fatalf("%s(%s, n=%d) not stable at %d", name, input, n, i)
+				}
+			}
+		}
+	}
+}
+
+func TestBottomUpMergeSort(t *testing.T) {
+	testStableSort(t, "BottomUpMergeSort", BottomUpMergeSort)
+}
+
+func TestNaturalMergeSort(t *testing.T) {
+	testStableSort(t, "NaturalMergeSort", NaturalMergeSort)
+}
+
+func TestCountRun(t *testing.T) {
+	tests := []struct {
+		in   Ints
+		end  int
+		want Ints
+	}{
+		{Ints{1}, 1, Ints{1}},
+		{Ints{1, 2, 2, 1}, 3, Ints{1, 2, 2, 1}},
+		{Ints{3, 2, 1, 1}, 3, Ints{1, 2, 3, 1}},
+	}
+	for _, test := range tests {
+		if end := countRun(test.in, 0, len(test.in)); end != test.end {
+			t.Errorf("countRun = %v; want %v", end, test.end)
+		}
+		for i := range test.want {
+			if test.in[i] != test.want[i] {
+				t.Errorf("countRun left %v; want %v", test.in, test.want)
+				break
+			}
+		}
+	}
+}
+
+// Values holds its elements boxed already, so At and Set do not allocate
+// and the allocation benchmarks count only calls to New.
+type Values []interface{}
+
+func (p Values) Len() int                   { return len(p) }
+func (p Values) Less(i, j interface{}) bool { return i.(int) < j.(int) }
+func (p Values) At(i int) interface{}       { return p[i] }
+func (p Values) Set(i int, v interface{})   { p[i] = v }
+func (p Values) New(i int) DupSortable      { return make(Values, i) }
+
+func benchmarkDupSort(b *testing.B, input string, sort func(DupSortable)) {
+	keys := inputs(1 << 16)[input]
+	in := make(Values, len(keys))
+	for i, k := range keys {
+		in[i] = k
+	}
+	s := make(Values, len(in))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(s, in)
+		sort(s)
+	}
+}
+
+func mergeSort(me DupSortable) {
+	sorted := MergeSort(me, 0, me.Len())
+	for i := 0; i < me.Len(); i++ {
+		me.Set(i, sorted.At(i))
+	}
+}
+
+func BenchmarkMergeSortRandom(b *testing.B) { benchmarkDupSort(b, "random", mergeSort) }
+func BenchmarkBottomUpMergeSortRandom(b *testing.B) {
+	benchmarkDupSort(b, "random", BottomUpMergeSort)
+}
+func BenchmarkNaturalMergeSortRandom(b *testing.B) {
+	benchmarkDupSort(b, "random", NaturalMergeSort)
+}
+func BenchmarkTimSortRandom(b *testing.B) { benchmarkDupSort(b, "random", TimSort) }
+func BenchmarkMergeSortNearSorted(b *testing.B) {
+	benchmarkDupSort(b, "nearSorted", mergeSort)
+}
+func BenchmarkBottomUpMergeSortNearSorted(b *testing.B) {
+	benchmarkDupSort(b, "nearSorted", BottomUpMergeSort)
+}
+func BenchmarkNaturalMergeSortNearSorted(b *testing.B) {
+	benchmarkDupSort(b, "nearSorted", NaturalMergeSort)
+}
+func BenchmarkTimSortNearSorted(b *testing.B) { benchmarkDupSort(b, "nearSorted", TimSort) }
@@ -0,0 +1,12 @@
+package dupsort
+
+// Sorts s in place by cmp with the same stable bottom up merge sort as
+// BottomUpMergeSort. Working on the slice directly rather than through
+// DupSortable avoids boxing every element in an interface{} on At and
+// Set, and calls cmp instead of Less on interfaces. cmp returns a
+// negative number if a sorts before b, a positive number if it sorts
+// after, and 0 if they are equal, as cmp.Compare does.
+func MergeSortFunc[T any](s []T, cmp func(a, b T) int) {
+	less := func(a, b T) bool { return cmp(a, b) < 0 }
+	bottomUp(slice[T](s), len(s), less, func() slice[T] { return make(slice[T], len(s)) })
+}
@@ -0,0 +1,45 @@
+package dupsort
+
+import (
+	"cmp"
+	"testing"
+)
+
+func TestMergeSortFunc(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 15, 16, 17, 100, 1000, 5000} {
+		for input, keys := range inputs(n) {
+			s := make([]tagged, n)
+			for i, k := range keys {
+				s[i] = tagged{k, i}
+			}
+			MergeSortFunc(s, func(a, b tagged) int { return cmp.Compare(a.key, b.key) })
+			for i := 1; i < n; i++ {
+				if s[i].key < s[i-1].key || s[i].key == s[i-1].key && s[i].tag < s[i-1].tag {
+					t.Fatalf("MergeSortFunc(%s, n=%d) not stably sorted at %d", input, n, i)
+				}
+			}
+		}
+	}
+}
+
+// The same bottom up merge sort on the same input, once through Ints,
+// whose At boxes every element it hands out and whose Less takes
+// interface{} values, and once through MergeSortFunc, which does neither.
+// The reported allocations show the boxing MergeSortFunc removes.
+func benchmarkMergeSortBoxing(b *testing.B, sort func([]int)) {
+	keys := inputs(1 << 16)["random"]
+	s := make([]int, len(keys))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(s, keys)
+		sort(s)
+	}
+}
+
+func BenchmarkMergeSortBoxed(b *testing.B) {
+	benchmarkMergeSortBoxing(b, func(s []int) { BottomUpMergeSort(Ints(s)) })
+}
+func BenchmarkMergeSortFunc(b *testing.B) {
+	benchmarkMergeSortBoxing(b, func(s []int) { MergeSortFunc(s, cmp.Compare[int]) })
+}
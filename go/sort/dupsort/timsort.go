@@ -0,0 +1,435 @@
+package dupsort
+
+const (
+	// Inputs shorter than this are binary insertion sorted.
+	minMerge = 32
+	// Elements won in a row by one run before a merge starts galloping.
+	minGallop = 7
+)
+
+// Holds the state of one TimSort: the pending runs and the scratch space.
+type timSort struct {
+	me        DupSortable
+	tmp       DupSortable // created on the first merge
+	minGallop int
+	runBase   []int
+	runLen    []int
+}
+
+// Returns the minimum run length for n elements: a length in
+// [minMerge/2, minMerge] such that n/minRun is a power of two or
+// just under one, which keeps the final merges balanced.
+func minRunLength(n int) int {
+	r := 0
+	for n >= minMerge {
+		r |= n & 1
+		n >>= 1
+	}
+	return n + r
+}
+
+// Sorts [lo, hi) of me given that [lo, start) is already sorted,
+// binary searching for where each new element goes.
+func binaryInsertionSort(me DupSortable, lo, hi, start int) {
+	for ; start < hi; start++ {
+		pivot := me.At(start)
+		l, r := lo, start
+		for l < r { // after every element equal to pivot, for stability
+			m := int(uint(l+r) >> 1)
+			if me.Less(pivot, me.At(m)) {
+				r = m
+			} else {
+				l = m + 1
+			}
+		}
+		copyRange(me, l, me, l+1, start-l)
+		me.Set(l, pivot)
+	}
+}
+
+// Returns the first position in the sorted [base, base+length) of a at
+// which key could be inserted, that is, before any elements equal to key.
+// The search gallops outward from base+hint before binary searching, so
+// it is fast when the answer is near the hint.
+func gallopLeft(key interface{}, a DupSortable, base, length, hint int) int {
+	lastOfs, ofs := 0, 1
+	if a.Less(a.At(base+hint), key) {
+		// gallop right until a[base+hint+lastOfs] < key <= a[base+hint+ofs]
+		maxOfs := length - hint
+		for ofs < maxOfs && a.Less(a.At(base+hint+ofs), key) {
+			lastOfs = ofs
+			ofs = ofs*2 + 1
+			if ofs <= 0 { // int overflow
+				ofs = maxOfs
+			}
+		}
+		ofs = min(ofs, maxOfs)
+		lastOfs += hint
+		ofs += hint
+	} else {
+		// gallop left until a[base+hint-ofs] < key <= a[base+hint-lastOfs]
+		maxOfs := hint + 1
+		for ofs < maxOfs && !a.Less(a.At(base+hint-ofs), key) {
+			lastOfs = ofs
+			ofs = ofs*2 + 1
+			if ofs <= 0 {
+				ofs = maxOfs
+			}
+		}
+		ofs = min(ofs, maxOfs)
+		lastOfs, ofs = hint-ofs, hint-lastOfs
+	}
+	// a[base+lastOfs] < key <= a[base+ofs]; binary search in between
+	for lastOfs++; lastOfs < ofs; {
+		m := lastOfs + (ofs-lastOfs)/2
+		if a.Less(a.At(base+m), key) {
+			lastOfs = m + 1
+		} else {
+			ofs = m
+		}
+	}
+	return ofs
+}
+
+// Like gallopLeft, but returns the position after any elements equal to key.
+func gallopRight(key interface{}, a DupSortable, base, length, hint int) int {
+	lastOfs, ofs := 0, 1
+	if a.Less(key, a.At(base+hint)) {
+		// gallop left until a[base+hint-ofs] <= key < a[base+hint-lastOfs]
+		maxOfs := hint + 1
+		for ofs < maxOfs && a.Less(key, a.At(base+hint-ofs)) {
+			lastOfs = ofs
+			ofs = ofs*2 + 1
+			if ofs <= 0 {
+				ofs = maxOfs
+			}
+		}
+		ofs = min(ofs, maxOfs)
+		lastOfs, ofs = hint-ofs, hint-lastOfs
+	} else {
+		// gallop right until a[base+hint+lastOfs] <= key < a[base+hint+ofs]
+		maxOfs := length - hint
+		for ofs < maxOfs && !a.Less(key, a.At(base+hint+ofs)) {
+			lastOfs = ofs
+			ofs = ofs*2 + 1
+			if ofs <= 0 {
+				ofs = maxOfs
+			}
+		}
+		ofs = min(ofs, maxOfs)
+		lastOfs += hint
+		ofs += hint
+	}
+	for lastOfs++; lastOfs < ofs; {
+		m := lastOfs + (ofs-lastOfs)/2
+		if a.Less(key, a.At(base+m)) {
+			ofs = m
+		} else {
+			lastOfs = m + 1
+		}
+	}
+	return ofs
+}
+
+// Sorts me in place with TimSort: a stable, natural merge sort that
+// extends short runs to a minimum length with binary insertion sort,
+// keeps pending runs on a stack whose lengths are merged in balanced
+// order, and gallops through a merge when one run keeps winning.
+// It calls New once, for at most n/2 elements of scratch space.
+// Runs in O(n * lg n) time and O(n) on input that is already
+// mostly sorted.
+func TimSort(me DupSortable) {
+	n := me.Len()
+	if n < 2 {
+		return
+	}
+	if n < minMerge {
+		binaryInsertionSort(me, 0, n, countRun(me, 0, n))
+		return
+	}
+	ts := &timSort{me: me, minGallop: minGallop}
+	minRun := minRunLength(n)
+	for lo := 0; lo < n; {
+		end := countRun(me, lo, n)
+		if end-lo < minRun {
+			force := min(lo+minRun, n)
+			binaryInsertionSort(me, lo, force, end)
+			end = force
+		}
+		ts.runBase = append(ts.runBase, lo)
+		ts.runLen = append(ts.runLen, end-lo)
+		ts.mergeCollapse()
+		lo = end
+	}
+	ts.mergeForceCollapse()
+}
+
+// Merges runs until the stack invariants hold again:
+//
+//	runLen[i-2] > runLen[i-1] + runLen[i]
+//	runLen[i-1] > runLen[i]
+//
+// checking the top four runs, which fixes the flaw in the original
+// algorithm that only checked the top three.
+func (ts *timSort) mergeCollapse() {
+	for len(ts.runLen) > 1 {
+		n := len(ts.runLen) - 2
+		l := ts.runLen
+		if n > 0 && l[n-1] <= l[n]+l[n+1] || n > 1 && l[n-2] <= l[n-1]+l[n] {
+			if l[n-1] < l[n+1] {
+				n--
+			}
+		} else if l[n] > l[n+1] {
+			return
+		}
+		ts.mergeAt(n)
+	}
+}
+
+// Merges every pending run.
+func (ts *timSort) mergeForceCollapse() {
+	for len(ts.runLen) > 1 {
+		n := len(ts.runLen) - 2
+		if n > 0 && ts.runLen[n-1] < ts.runLen[n+1] {
+			n--
+		}
+		ts.mergeAt(n)
+	}
+}
+
+// Merges the runs at i and i+1 of the stack.
+func (ts *timSort) mergeAt(i int) {
+	me := ts.me
+	base1, len1 := ts.runBase[i], ts.runLen[i]
+	base2, len2 := ts.runBase[i+1], ts.runLen[i+1]
+	ts.runLen[i] = len1 + len2
+	if last := len(ts.runLen) - 1; i == last-2 {
+		ts.runBase[i+1] = ts.runBase[last]
+		ts.runLen[i+1] = ts.runLen[last]
+	}
+	ts.runBase = ts.runBase[:len(ts.runBase)-1]
+	ts.runLen = ts.runLen[:len(ts.runLen)-1]
+
+	// the start of run1 that is not greater than run2's first element is already in place
+	k := gallopRight(me.At(base2), me, base1, len1, 0)
+	base1 += k
+	len1 -= k
+	if len1 == 0 {
+		return
+	}
+	// as is the end of run2 that is not less than run1's last element
+	len2 = gallopLeft(me.At(base1+len1-1), me, base2, len2, len2-1)
+	if len2 == 0 {
+		return
+	}
+	if ts.tmp == nil {
+		ts.tmp = me.New(me.Len() / 2)
+	}
+	if len1 <= len2 {
+		ts.mergeLo(base1, len1, base2, len2)
+	} else {
+		ts.mergeHi(base1, len1, base2, len2)
+	}
+}
+
+// Merges adjacent runs where run1 is the shorter, copying run1 into tmp
+// and merging from the front. run1's first element must be greater than
+// run2's first and run1's last element must be greater than run2's last.
+func (ts *timSort) mergeLo(base1, len1, base2, len2 int) {
+	a, tmp := ts.me, ts.tmp
+	copyRange(a, base1, tmp, 0, len1)
+	cursor1, cursor2, dest := 0, base2, base1
+	a.Set(dest, a.At(cursor2))
+	dest++
+	cursor2++
+	if len2--; len2 == 0 {
+		copyRange(tmp, cursor1, a, dest, len1)
+		return
+	}
+	if len1 == 1 {
+		copyRange(a, cursor2, a, dest, len2)
+		a.Set(dest+len2, tmp.At(cursor1))
+		return
+	}
+	mg := ts.minGallop
+outer:
+	for {
+		count1, count2 := 0, 0 // times in a row each run won
+		for {
+			if a.Less(a.At(cursor2), tmp.At(cursor1)) {
+				a.Set(dest, a.At(cursor2))
+				dest++
+				cursor2++
+				count2++
+				count1 = 0
+				if len2--; len2 == 0 {
+					break outer
+				}
+			} else {
+				a.Set(dest, tmp.At(cursor1))
+				dest++
+				cursor1++
+				count1++
+				count2 = 0
+				if len1--; len1 == 1 {
+					break outer
+				}
+			}
+			if count1|count2 >= mg {
+				break
+			}
+		}
+		// one run is winning consistently; gallop until neither is
+		for {
+			count1 = gallopRight(a.At(cursor2), tmp, cursor1, len1, 0)
+			if count1 != 0 {
+				copyRange(tmp, cursor1, a, dest, count1)
+				dest += count1
+				cursor1 += count1
+				len1 -= count1
+				if len1 <= 1 {
+					break outer
+				}
+			}
+			a.Set(dest, a.At(cursor2))
+			dest++
+			cursor2++
+			if len2--; len2 == 0 {
+				break outer
+			}
+			count2 = gallopLeft(tmp.At(cursor1), a, cursor2, len2, 0)
+			if count2 != 0 {
+				copyRange(a, cursor2, a, dest, count2)
+				dest += count2
+				cursor2 += count2
+				len2 -= count2
+				if len2 == 0 {
+					break outer
+				}
+			}
+			a.Set(dest, tmp.At(cursor1))
+			dest++
+			cursor1++
+			if len1--; len1 == 1 {
+				break outer
+			}
+			mg--
+			if count1 < minGallop && count2 < minGallop {
+				break
+			}
+		}
+		mg = max(mg, 0) + 2 // penalize leaving gallop mode
+	}
+	ts.minGallop = max(mg, 1)
+	switch len1 {
+	case 1:
+		copyRange(a, cursor2, a, dest, len2)
+		a.Set(dest+len2, tmp.At(cursor1))
+	case 0:
+		panic("TimSort: Less is not a strict weak ordering")
+	default:
+		copyRange(tmp, cursor1, a, dest, len1)
+	}
+}
+
+// Like mergeLo, but for when run2 is the shorter: run2 is copied into
+// tmp and the runs are merged from the back.
+func (ts *timSort) mergeHi(base1, len1, base2, len2 int) {
+	a, tmp := ts.me, ts.tmp
+	copyRange(a, base2, tmp, 0, len2)
+	cursor1, cursor2, dest := base1+len1-1, len2-1, base2+len2-1
+	a.Set(dest, a.At(cursor1))
+	dest--
+	cursor1--
+	if len1--; len1 == 0 {
+		copyRange(tmp, 0, a, dest-(len2-1), len2)
+		return
+	}
+	if len2 == 1 {
+		dest -= len1
+		cursor1 -= len1
+		copyRange(a, cursor1+1, a, dest+1, len1)
+		a.Set(dest, tmp.At(cursor2))
+		return
+	}
+	mg := ts.minGallop
+outer:
+	for {
+		count1, count2 := 0, 0
+		for {
+			if a.Less(tmp.At(cursor2), a.At(cursor1)) {
+				a.Set(dest, a.At(cursor1))
+				dest--
+				cursor1--
+				count1++
+				count2 = 0
+				if len1--; len1 == 0 {
+					break outer
+				}
+			} else {
+				a.Set(dest, tmp.At(cursor2))
+				dest--
+				cursor2--
+				count2++
+				count1 = 0
+				if len2--; len2 == 1 {
+					break outer
+				}
+			}
+			if count1|count2 >= mg {
+				break
+			}
+		}
+		for {
+			count1 = len1 - gallopRight(tmp.At(cursor2), a, base1, len1, len1-1)
+			if count1 != 0 {
+				dest -= count1
+				cursor1 -= count1
+				len1 -= count1
+				copyRange(a, cursor1+1, a, dest+1, count1)
+				if len1 == 0 {
+					break outer
+				}
+			}
+			a.Set(dest, tmp.At(cursor2))
+			dest--
+			cursor2--
+			if len2--; len2 == 1 {
+				break outer
+			}
+			count2 = len2 - gallopLeft(a.At(cursor1), tmp, 0, len2, len2-1)
+			if count2 != 0 {
+				dest -= count2
+				cursor2 -= count2
+				len2 -= count2
+				copyRange(tmp, cursor2+1, a, dest+1, count2)
+				if len2 <= 1 {
+					break outer
+				}
+			}
+			a.Set(dest, a.At(cursor1))
+			dest--
+			cursor1--
+			if len1--; len1 == 0 {
+				break outer
+			}
+			mg--
+			if count1 < minGallop && count2 < minGallop {
+				break
+			}
+		}
+		mg = max(mg, 0) + 2
+	}
+	ts.minGallop = max(mg, 1)
+	switch len2 {
+	case 1:
+		dest -= len1
+		cursor1 -= len1
+		copyRange(a, cursor1+1, a, dest+1, len1)
+		a.Set(dest, tmp.At(cursor2))
+	case 0:
+		panic("TimSort: Less is not a strict weak ordering")
+	default:
+		copyRange(tmp, 0, a, dest-(len2-1), len2)
+	}
+}
@@ -0,0 +1,59 @@
+package dupsort
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestTimSort(t *testing.T) {
+	testStableSort(t, "TimSort", TimSort)
+}
+
+// Long runs that interleave in big blocks make merges gallop.
+func TestTimSortGallop(t *testing.T) {
+	r := rand.New(rand.NewSource(39))
+	for trial := 0; trial < 20; trial++ {
+		n := 1000 + r.Intn(5000)
+		s := make(taggeds, n)
+		for i := range s {
+			block := r.Intn(3) * 1000
+			s[i] = tagged{block + r.Intn(1000), i}
+		}
+		// sort each quarter so there are long runs to merge
+		for q := 0; q < 4; q++ {
+			BottomUpMergeSort(s[q*n/4 : (q+1)*n/4])
+		}
+		TimSort(s)
+		for i := 1; i < n; i++ {
+			if s[i].key < s[i-1].key || s[i].key == s[i-1].key && s[i].tag < s[i-1].tag {
+				t.Fatalf("TimSort not stably sorted at %d", i)
+			}
+		}
+	}
+}
+
+func TestMinRunLength(t *testing.T) {
+	for n := minMerge; n < 1<<16; n = n*3 + 1 {
+		if r := minRunLength(n); r < minMerge/2 || r > minMerge {
+			t.Errorf("minRunLength(%d) = %d; want in [%d, %d]", n, r, minMerge/2, minMerge)
+		}
+	}
+}
+
+func TestGallop(t *testing.T) {
+	s := Ints{1, 2, 2, 2, 3, 5, 8}
+	for hint := 0; hint < len(s); hint++ {
+		if got := gallopLeft(2, s, 0, len(s), hint); got != 1 {
+			t.Errorf("gallopLeft(2, hint %d) = %d; want 1", hint, got)
+		}
+		if got := gallopRight(2, s, 0, len(s), hint); got != 4 {
+			t.Errorf("gallopRight(2, hint %d) = %d; want 4", hint, got)
+		}
+		if got := gallopLeft(9, s, 0, len(s), hint); got != 7 {
+			t.Errorf("gallopLeft(9, hint %d) = %d; want 7", hint, got)
+		}
+		if got := gallopRight(0, s, 0, len(s), hint); got != 0 {
+			t.Errorf("gallopRight(0, hint %d) = %d; want 0", hint, got)
+		}
+	}
+}
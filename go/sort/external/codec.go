@@ -0,0 +1,60 @@
+package external
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"strings"
+)
+
+// LineCodec reads and writes records that are lines of text. A record
+// is written followed by a newline and read back without it; a last
+// line that is missing its newline is still read. Records must not
+// contain newlines.
+type LineCodec struct{}
+
+// Decode reads the next line from r, without its newline. It returns
+// io.EOF once r has no more bytes, and any other error from r as is.
+func (LineCodec) Decode(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		return line, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\n"), nil
+}
+
+// Encode writes v and a newline to w.
+func (LineCodec) Encode(w *bufio.Writer, v string) error {
+	if _, err := w.WriteString(v); err != nil {
+		return err
+	}
+	return w.WriteByte('\n')
+}
+
+// LineSize is a Sorter.Size for strings, counting their bytes and header.
+func LineSize(v string) int {
+	return len(v) + 16
+}
+
+// BinaryCodec reads and writes fixed size records, such as numbers or
+// structs of numbers, with encoding/binary in the given byte order.
+// A record cut short at the end of the input is an io.ErrUnexpectedEOF.
+type BinaryCodec[T any] struct {
+	Order binary.ByteOrder
+}
+
+// Decode reads the next record from r. It returns io.EOF if r has no
+// more bytes, and io.ErrUnexpectedEOF if r ends partway through a record.
+func (c BinaryCodec[T]) Decode(r *bufio.Reader) (T, error) {
+	var v T
+	err := binary.Read(r, c.Order, &v)
+	return v, err
+}
+
+// Encode writes the binary.Size(v) bytes of v to w.
+func (c BinaryCodec[T]) Encode(w *bufio.Writer, v T) error {
+	return binary.Write(w, c.Order, v)
+}
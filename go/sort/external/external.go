@@ -0,0 +1,241 @@
+// Package external sorts streams of records that are too big to sort
+// in memory. Records are read in chunks that fit in a memory budget,
+// each chunk is sorted and spilled to a temporary file as a run, and
+// the runs are merged k ways at a time with package merge until one is left.
+package external
+
+import (
+	"bufio"
+	"errors"
+	"github.com/twmb/algoimpl/go/sort/merge"
+	"io"
+	"iter"
+	"os"
+	"sort"
+	"unsafe"
+)
+
+const (
+	// DefaultMemoryLimit is the memory budget used when Sorter.MemoryLimit is 0.
+	DefaultMemoryLimit = 64 << 20
+	// DefaultFanIn is the number of runs merged at once when Sorter.FanIn is 0.
+	DefaultFanIn = 64
+)
+
+// Codec reads and writes records of type T. Runs are written with
+// the same Codec that reads the input, so Decode must read back
+// anything Encode writes.
+type Codec[T any] interface {
+	// Decode reads the next record from r.
+	// It returns io.EOF, and only io.EOF, once r has no more records.
+	Decode(r *bufio.Reader) (T, error)
+	// Encode writes v to w.
+	Encode(w *bufio.Writer, v T) error
+}
+
+// Sorter sorts the records read from an io.Reader to an io.Writer.
+// Codec and Less must be set; the other fields have usable defaults.
+type Sorter[T any] struct {
+	// Codec reads and writes the records.
+	Codec Codec[T]
+	// Less returns whether a sorts before b. Records that are equal under
+	// Less keep their input order.
+	Less func(a, b T) bool
+	// MemoryLimit is about how many bytes of records are held in memory
+	// before they are sorted and spilled to a run. If 0, DefaultMemoryLimit is used.
+	MemoryLimit int
+	// Size returns about how many bytes a record takes up in memory. If nil,
+	// the size of T itself is used, which undercounts records that point
+	// to more memory, such as strings and slices.
+	Size func(T) int
+	// FanIn is the most runs merged at once, which bounds the number of
+	// open files. If there are more runs, they are merged in more than
+	// one pass. If 0, DefaultFanIn is used; values below 2 are raised to 2.
+	FanIn int
+	// TempDir is the directory runs are written to. If empty, os.TempDir is used.
+	TempDir string
+}
+
+func (s *Sorter[T]) size(v T) int {
+	if s.Size != nil {
+		return s.Size(v)
+	}
+	return int(unsafe.Sizeof(v))
+}
+
+// Sort reads every record from r and writes them to w in sorted order.
+// Temporary files are removed before Sort returns, even on error.
+func (s *Sorter[T]) Sort(r io.Reader, w io.Writer) (err error) {
+	if s.Codec == nil || s.Less == nil {
+		return errors.New("Sort: Codec and Less must be set")
+	}
+	limit := s.MemoryLimit
+	if limit <= 0 {
+		limit = DefaultMemoryLimit
+	}
+	fanIn := s.FanIn
+	if fanIn == 0 {
+		fanIn = DefaultFanIn
+	}
+	fanIn = max(fanIn, 2)
+
+	var runs []string
+	created := map[string]bool{} // every run not yet removed
+	defer func() {
+		for run := range created {
+			if rmErr := os.Remove(run); rmErr != nil && err == nil {
+				err = rmErr
+			}
+		}
+	}()
+	keep := func(run string) {
+		if run != "" {
+			created[run] = true
+		}
+	}
+
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+	var chunk []T
+	for eof := false; !eof; {
+		chunk = chunk[:0]
+		for used := 0; used < limit; {
+			v, err := s.Codec.Decode(br)
+			if err == io.EOF {
+				eof = true
+				break
+			}
+			if err != nil {
+				return err
+			}
+			chunk = append(chunk, v)
+			used += s.size(v)
+		}
+		sort.SliceStable(chunk, func(i, j int) bool { return s.Less(chunk[i], chunk[j]) })
+		if eof && len(runs) == 0 { // everything fit in memory
+			if err := s.writeAll(bw, chunk); err != nil {
+				return err
+			}
+			return bw.Flush()
+		}
+		if len(chunk) == 0 {
+			break
+		}
+		run, err := s.spill(chunk)
+		keep(run)
+		if err != nil {
+			return err
+		}
+		runs = append(runs, run)
+	}
+	chunk = nil // garbage collect
+
+	// merge consecutive groups of fanIn runs, keeping the runs in input
+	// order for stability, until they fit in one last merge
+	for len(runs) > fanIn {
+		var next []string
+		for i := 0; i < len(runs); i += fanIn {
+			group := runs[i:min(i+fanIn, len(runs))]
+			if len(group) == 1 {
+				next = append(next, group[0])
+				continue
+			}
+			merged, err := s.mergeToRun(group)
+			keep(merged)
+			if err != nil {
+				return err
+			}
+			next = append(next, merged)
+			for _, run := range group {
+				if err := os.Remove(run); err != nil {
+					return err
+				}
+				delete(created, run)
+			}
+		}
+		runs = next
+	}
+	if err := s.mergeRuns(runs, bw); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func (s *Sorter[T]) writeAll(bw *bufio.Writer, records []T) error {
+	for _, v := range records {
+		if err := s.Codec.Encode(bw, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Creates a temporary file for a run and lets write fill it.
+// The file's name is returned even on error so that it can be removed.
+func (s *Sorter[T]) createRun(write func(*bufio.Writer) error) (name string, err error) {
+	f, err := os.CreateTemp(s.TempDir, "external-run-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+	bw := bufio.NewWriter(f)
+	if err := write(bw); err != nil {
+		return f.Name(), err
+	}
+	return f.Name(), bw.Flush()
+}
+
+// Writes sorted records to a new run.
+func (s *Sorter[T]) spill(sorted []T) (string, error) {
+	return s.createRun(func(bw *bufio.Writer) error {
+		return s.writeAll(bw, sorted)
+	})
+}
+
+// Merges runs into a new run.
+func (s *Sorter[T]) mergeToRun(runs []string) (string, error) {
+	return s.createRun(func(bw *bufio.Writer) error {
+		return s.mergeRuns(runs, bw)
+	})
+}
+
+// Merges runs, which must be in input order, to bw.
+func (s *Sorter[T]) mergeRuns(runs []string, bw *bufio.Writer) error {
+	var decodeErr error
+	seqs := make([]iter.Seq[T], len(runs))
+	for i, run := range runs {
+		f, err := os.Open(run)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r := bufio.NewReader(f)
+		seqs[i] = func(yield func(T) bool) {
+			for {
+				v, err := s.Codec.Decode(r)
+				if err != nil {
+					if err != io.EOF && decodeErr == nil {
+						decodeErr = err
+					}
+					return
+				}
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+	for v := range merge.Seqs(s.Less, seqs) {
+		if decodeErr != nil {
+			break
+		}
+		if err := s.Codec.Encode(bw, v); err != nil {
+			return err
+		}
+	}
+	return decodeErr
+}
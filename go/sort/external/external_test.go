@@ -0,0 +1,119 @@
+package external
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSortLines(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	lines := make([]string, 20000)
+	for i := range lines {
+		lines[i] = strconv.Itoa(r.Intn(1 << 30))
+	}
+	in := strings.Join(lines, "\n") // no trailing newline
+	sort.Strings(lines)
+	want := strings.Join(lines, "\n") + "\n"
+
+	for _, test := range []struct {
+		limit, fanIn int
+	}{
+		{0, 0},        // in memory
+		{1 << 14, 0},  // one merge
+		{1 << 12, 3},  // many passes
+		{1 << 12, 64}, // one wide merge
+	} {
+		dir := t.TempDir()
+		s := &Sorter[string]{
+			Codec:       LineCodec{},
+			Less:        func(a, b string) bool { return a < b },
+			MemoryLimit: test.limit,
+			Size:        LineSize,
+			FanIn:       test.fanIn,
+			TempDir:     dir,
+		}
+		var out bytes.Buffer
+		if err := s.Sort(strings.NewReader(in), &out); err != nil {
+			t.Fatalf("Sort(limit=%d, fanIn=%d): %v", test.limit, test.fanIn, err)
+		}
+		if out.String() != want {
+			t.Errorf("Sort(limit=%d, fanIn=%d) output not sorted", test.limit, test.fanIn)
+		}
+		if left, _ := os.ReadDir(dir); len(left) != 0 {
+			t.Errorf("Sort(limit=%d, fanIn=%d) left %d temporary files", test.limit, test.fanIn, len(left))
+		}
+	}
+}
+
+type record struct {
+	Key, Tag uint32
+}
+
+func TestSortStable(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	var in bytes.Buffer
+	for i := 0; i < 10000; i++ {
+		binary.Write(&in, binary.LittleEndian, record{uint32(r.Intn(10)), uint32(i)})
+	}
+	s := &Sorter[record]{
+		Codec:       BinaryCodec[record]{binary.LittleEndian},
+		Less:        func(a, b record) bool { return a.Key < b.Key },
+		MemoryLimit: 1000,
+		FanIn:       4,
+		TempDir:     t.TempDir(),
+	}
+	var out bytes.Buffer
+	if err := s.Sort(&in, &out); err != nil {
+		t.Fatal(err)
+	}
+	br := bufio.NewReader(&out)
+	var prev record
+	for i := 0; ; i++ {
+		v, err := s.Codec.Decode(br)
+		if err == io.EOF {
+			if i != 10000 {
+				t.Fatalf("Sort wrote %d records; want 10000", i)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i > 0 && (v.Key < prev.Key || v.Key == prev.Key && v.Tag < prev.Tag) {
+			t.Fatalf("Sort not stable at record %d: %v after %v", i, v, prev)
+		}
+		prev = v
+	}
+}
+
+func TestSortErrors(t *testing.T) {
+	dir := t.TempDir()
+	s := &Sorter[uint64]{
+		Codec:       BinaryCodec[uint64]{binary.BigEndian},
+		Less:        func(a, b uint64) bool { return a < b },
+		MemoryLimit: 64,
+		TempDir:     dir,
+	}
+	in := make([]byte, 8*100+3) // a truncated last record after spilling runs
+	if err := s.Sort(bytes.NewReader(in), io.Discard); err != io.ErrUnexpectedEOF {
+		t.Errorf("Sort of truncated input = %v; want %v", err, io.ErrUnexpectedEOF)
+	}
+	if left, _ := os.ReadDir(dir); len(left) != 0 {
+		t.Errorf("Sort left %d temporary files after an error", len(left))
+	}
+	if err := (&Sorter[string]{}).Sort(strings.NewReader(""), io.Discard); err == nil {
+		t.Errorf("Sort without a Codec succeeded")
+	}
+	var out bytes.Buffer
+	if err := s.Sort(bytes.NewReader(nil), &out); err != nil || out.Len() != 0 {
+		t.Errorf("Sort of empty input = %q, %v", out.Bytes(), err)
+	}
+}
@@ -0,0 +1,51 @@
+package sort
+
+// Adapts a slice and a comparison function to Sortable,
+// so the Func entry points share the algorithms above.
+type funcSortable[T any] struct {
+	s   []T
+	cmp func(a, b T) int
+}
+
+func (f funcSortable[T]) Len() int           { return len(f.s) }
+func (f funcSortable[T]) Less(i, j int) bool { return f.cmp(f.s[i], f.s[j]) < 0 }
+func (f funcSortable[T]) Swap(i, j int)      { f.s[i], f.s[j] = f.s[j], f.s[i] }
+
+// The Func variants sort a slice directly, without a Sortable wrapper type.
+// cmp returns a negative number if a sorts before b, a positive number
+// if it sorts after, and 0 if they are equal, as cmp.Compare does.
+
+// Runs InsertionSort on s ordered by cmp.
+func InsertionSortFunc[T any](s []T, cmp func(a, b T) int) {
+	InsertionSort(funcSortable[T]{s, cmp})
+}
+
+// Runs QuickSort on s ordered by cmp.
+func QuickSortFunc[T any](s []T, cmp func(a, b T) int) {
+	QuickSort(funcSortable[T]{s, cmp})
+}
+
+// Runs HeapSort on s ordered by cmp.
+func HeapSortFunc[T any](s []T, cmp func(a, b T) int) {
+	HeapSort(funcSortable[T]{s, cmp})
+}
+
+// Runs IntroSort on s ordered by cmp.
+func IntroSortFunc[T any](s []T, cmp func(a, b T) int) {
+	IntroSort(funcSortable[T]{s, cmp})
+}
+
+// Runs ShellSort on s ordered by cmp.
+func ShellSortFunc[T any](s []T, cmp func(a, b T) int) {
+	ShellSort(funcSortable[T]{s, cmp})
+}
+
+// Runs StableSort on s ordered by cmp.
+func StableSortFunc[T any](s []T, cmp func(a, b T) int) {
+	StableSort(funcSortable[T]{s, cmp})
+}
+
+// Returns whether s is sorted by cmp.
+func IsSortedFunc[T any](s []T, cmp func(a, b T) int) bool {
+	return IsSorted(funcSortable[T]{s, cmp})
+}
@@ -0,0 +1,60 @@
+package sort
+
+import (
+	"cmp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var funcSorts = map[string]func([]int, func(a, b int) int){
+	"InsertionSortFunc": InsertionSortFunc[int],
+	"QuickSortFunc":     QuickSortFunc[int],
+	"HeapSortFunc":      HeapSortFunc[int],
+	"IntroSortFunc":     IntroSortFunc[int],
+	"ShellSortFunc":     ShellSortFunc[int],
+	"StableSortFunc":    StableSortFunc[int],
+}
+
+func TestFuncSorts(t *testing.T) {
+	for name, sortFunc := range funcSorts {
+		for input, in := range adversarialInputs(300) {
+			s := append([]int(nil), in...)
+			sortFunc(s, cmp.Compare[int])
+			if !IsSortedFunc(s, cmp.Compare[int]) {
+				t.Errorf("%s(%s) not sorted", name, input)
+			}
+			want := append([]int(nil), in...)
+			sort.Ints(want)
+			for i := range want {
+				if s[i] != want[i] {
+					t.Errorf("%s(%s) lost elements", name, input)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestStableSortFunc(t *testing.T) {
+	words := strings.Fields("pear fig apple kiwi plum date lime yuzu")
+	StableSortFunc(words, func(a, b string) int { return len(a) - len(b) })
+	want := strings.Fields("fig pear kiwi plum date lime yuzu apple")
+	for i := range want {
+		if words[i] != want[i] {
+			t.Fatalf("StableSortFunc = %v; want %v", words, want)
+		}
+	}
+}
+
+// QuickSortFunc needs no wrapper type but goes through Sortable too,
+// paying one extra indirect call to cmp per comparison. Compare with
+// BenchmarkQuickSortRandom, which sorts the same input through Ints.
+func BenchmarkQuickSortFunc(b *testing.B) {
+	in := adversarialInputs(1 << 14)["random"]
+	s := make([]int, len(in))
+	for i := 0; i < b.N; i++ {
+		copy(s, in)
+		QuickSortFunc(s, cmp.Compare[int])
+	}
+}
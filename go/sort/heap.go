@@ -1,39 +1,46 @@
 package sort
 
-// Shuffles a smaller value at index i in a heap
-// down to the appropriate spot. Complexity is O(lg n).
-func shuffleDown(heap Sortable, i, end int) {
+// Shuffles a smaller value at index i in a heap that starts at
+// index from down to the appropriate spot. i and end are relative
+// to from. Complexity is O(lg n).
+func shuffleDown(heap Sortable, from, i, end int) {
 	for {
 		l := 2*i + 1
-		if l >= end { // int overflow? (in go source)
+		if l >= end || l < 0 { // l < 0 on int overflow
 			break
 		}
 		li := l
-		if r := l + 1; r < end && heap.Less(l, r) {
+		if r := l + 1; r < end && heap.Less(from+l, from+r) {
 			li = r // 2*i + 2
 		}
-		if heap.Less(li, i) {
+		if heap.Less(from+li, from+i) {
 			break
 		}
-		heap.Swap(li, i)
+		heap.Swap(from+li, from+i)
 		i = li
 	}
 }
 
-// Creates a max heap out of an unorganized Sortable collection.
+// Creates a max heap out of the unorganized elements in [from, to).
 // Runs in O(n) time.
-func buildHeap(stuff Sortable) {
-	for i := stuff.Len()/2 - 1; i >= 0; i-- { // start at first non leaf (equiv. to parent of last leaf)
-		shuffleDown(stuff, i, stuff.Len())
+func buildHeap(stuff Sortable, from, to int) {
+	n := to - from
+	for i := n/2 - 1; i >= 0; i-- { // start at first non leaf (equiv. to parent of last leaf)
+		shuffleDown(stuff, from, i, n)
 	}
 }
 
 // Runs HeapSort on a Sortable collection.
 // Runs in O(n * lg n) time, but amortizes worse than quicksort
 func HeapSort(stuff Sortable) {
-	buildHeap(stuff)
-	for i := stuff.Len() - 1; i > 0; i-- {
-		stuff.Swap(0, i) // put max at end
-		shuffleDown(stuff, 0, i)
+	HeapSortRange(stuff, 0, stuff.Len())
+}
+
+// Runs HeapSort on the elements in [from, to) of a Sortable collection.
+func HeapSortRange(stuff Sortable, from, to int) {
+	buildHeap(stuff, from, to)
+	for i := to - from - 1; i > 0; i-- {
+		stuff.Swap(from, from+i) // put max at end
+		shuffleDown(stuff, from, 0, i)
 	}
 }
@@ -28,3 +28,7 @@ func TestHeapSort(t *testing.T) {
 		}
 	}
 }
+
+func TestHeapSortRange(t *testing.T) {
+	testSortRange(t, "HeapSortRange", HeapSortRange)
+}
@@ -0,0 +1,183 @@
+// Package instrument counts, and can record, the operations sort
+// algorithms perform on their input, to compare algorithms by more
+// than their running time. Wrap a sort.Sortable or dupsort.DupSortable
+// with a Recorder, sort the wrapper, and read the counts off the Recorder.
+package instrument
+
+import (
+	"fmt"
+	"github.com/twmb/algoimpl/go/sort"
+	"github.com/twmb/algoimpl/go/sort/dupsort"
+	"io"
+)
+
+// OpKind is the kind of an operation on a collection being sorted.
+type OpKind int
+
+const (
+	// OpLess is a call to Less, on a Sortable or a DupSortable.
+	OpLess OpKind = iota
+	// OpSwap is a call to Swap on a Sortable.
+	OpSwap
+	// OpAt is a call to At on a DupSortable.
+	OpAt
+	// OpSet is a call to Set on a DupSortable.
+	OpSet
+	// OpNew is a call to New on a DupSortable, which allocates scratch space.
+	OpNew
+)
+
+// Op is one recorded operation. For OpLess and OpSwap, I and J are the
+// indices passed in and, for OpLess, Result is what Less returned.
+// For OpAt and OpSet, I is the index. For OpNew, I is the length asked for.
+// Ops on DupSortables cannot tell which collection they were made on,
+// as the values passed to Less have no index.
+type Op struct {
+	Kind   OpKind
+	I, J   int
+	Result bool
+}
+
+func (o Op) String() string {
+	switch o.Kind {
+	case OpLess:
+		return fmt.Sprintf("less(%d, %d) = %v", o.I, o.J, o.Result)
+	case OpSwap:
+		return fmt.Sprintf("swap(%d, %d)", o.I, o.J)
+	case OpAt:
+		return fmt.Sprintf("at(%d)", o.I)
+	case OpSet:
+		return fmt.Sprintf("set(%d)", o.I)
+	case OpNew:
+		return fmt.Sprintf("new(%d)", o.I)
+	}
+	return fmt.Sprintf("Op(%d)", int(o.Kind))
+}
+
+// Recorder counts the operations made on the collections wrapped with it.
+type Recorder struct {
+	Less, Swap, At, Set int
+	// New is the number of calls to New and Allocated the total
+	// number of elements they asked for.
+	New, Allocated int
+	// Trace holds every operation in order if the Recorder is tracing.
+	Trace []Op
+	trace bool
+}
+
+// NewRecorder returns a Recorder that also records a trace of every
+// operation if trace is true. Traces of large sorts are large.
+func NewRecorder(trace bool) *Recorder {
+	return &Recorder{trace: trace}
+}
+
+// Reset zeroes the counts and empties the trace.
+func (r *Recorder) Reset() {
+	*r = Recorder{Trace: r.Trace[:0], trace: r.trace}
+}
+
+func (r *Recorder) record(op Op) {
+	if r.trace {
+		r.Trace = append(r.Trace, op)
+	}
+}
+
+type sortable struct {
+	s sort.Sortable
+	r *Recorder
+}
+
+// Sortable returns s wrapped so that r counts every Less and Swap made on it.
+func (r *Recorder) Sortable(s sort.Sortable) sort.Sortable {
+	return sortable{s, r}
+}
+
+func (w sortable) Len() int { return w.s.Len() }
+
+func (w sortable) Less(i, j int) bool {
+	less := w.s.Less(i, j)
+	w.r.Less++
+	w.r.record(Op{OpLess, i, j, less})
+	return less
+}
+
+func (w sortable) Swap(i, j int) {
+	w.s.Swap(i, j)
+	w.r.Swap++
+	w.r.record(Op{Kind: OpSwap, I: i, J: j})
+}
+
+type dupSortable struct {
+	s dupsort.DupSortable
+	r *Recorder
+}
+
+// DupSortable returns s wrapped so that r counts every Less, At, Set and
+// New made on it and on every collection that New returns.
+func (r *Recorder) DupSortable(s dupsort.DupSortable) dupsort.DupSortable {
+	return dupSortable{s, r}
+}
+
+func (w dupSortable) Len() int { return w.s.Len() }
+
+func (w dupSortable) Less(i, j interface{}) bool {
+	less := w.s.Less(i, j)
+	w.r.Less++
+	w.r.record(Op{Kind: OpLess, I: -1, J: -1, Result: less})
+	return less
+}
+
+func (w dupSortable) At(i int) interface{} {
+	w.r.At++
+	w.r.record(Op{Kind: OpAt, I: i})
+	return w.s.At(i)
+}
+
+func (w dupSortable) Set(i int, v interface{}) {
+	w.r.Set++
+	w.r.record(Op{Kind: OpSet, I: i})
+	w.s.Set(i, v)
+}
+
+func (w dupSortable) New(i int) dupsort.DupSortable {
+	w.r.New++
+	w.r.Allocated += i
+	w.r.record(Op{Kind: OpNew, I: i})
+	return dupSortable{w.s.New(i), w.r}
+}
+
+// Replay writes values as they were after every swap in trace, which must
+// be a trace of sorting values through Recorder.Sortable, one line per swap.
+// The two values each swap moved are marked with a *, so a small sort
+// can be followed step by step.
+func Replay(w io.Writer, values []int, trace []Op) error {
+	values = append([]int(nil), values...)
+	if err := writeValues(w, "", values, -1, -1); err != nil {
+		return err
+	}
+	for _, op := range trace {
+		if op.Kind != OpSwap {
+			continue
+		}
+		values[op.I], values[op.J] = values[op.J], values[op.I]
+		if err := writeValues(w, op.String(), values, op.I, op.J); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeValues(w io.Writer, label string, values []int, i, j int) error {
+	line := make([]byte, 0, 4*len(values))
+	for k, v := range values {
+		if k > 0 {
+			line = append(line, ' ')
+		}
+		line = fmt.Appendf(line, "%d", v)
+		if k == i || k == j {
+			line = append(line, '*')
+		}
+	}
+	_, err := fmt.Fprintf(w, "%12s [%s]\n", label, line)
+	return err
+}
@@ -0,0 +1,101 @@
+package instrument
+
+import (
+	"bytes"
+	"github.com/twmb/algoimpl/go/sort"
+	"github.com/twmb/algoimpl/go/sort/dupsort"
+	"strings"
+	"testing"
+)
+
+func TestRecorderSortable(t *testing.T) {
+	const n = 50
+	r := NewRecorder(false)
+	sort.InsertionSort(r.Sortable(ints(Generate("sorted", n, 1))))
+	if r.Less != n-1 || r.Swap != 0 {
+		t.Errorf("InsertionSort of sorted input: %d less, %d swap; want %d, 0", r.Less, r.Swap, n-1)
+	}
+	r.Reset()
+	s := ints(Generate("reversed", n, 1))
+	sort.InsertionSort(r.Sortable(s))
+	if want := n * (n - 1) / 2; r.Swap != want {
+		t.Errorf("InsertionSort of reversed input: %d swaps; want %d", r.Swap, want)
+	}
+	if !sort.IsSorted(s) {
+		t.Errorf("wrapped collection not sorted")
+	}
+	if r.Trace != nil {
+		t.Errorf("Recorder not tracing recorded %d ops", len(r.Trace))
+	}
+}
+
+func TestRecorderTrace(t *testing.T) {
+	r := NewRecorder(true)
+	s := ints{3, 1, 2}
+	sort.InsertionSort(r.Sortable(s))
+	want := []Op{
+		{OpLess, 1, 0, true}, {Kind: OpSwap, I: 1, J: 0},
+		{OpLess, 2, 1, true}, {Kind: OpSwap, I: 2, J: 1},
+		{OpLess, 1, 0, false},
+	}
+	if len(r.Trace) != len(want) {
+		t.Fatalf("Trace = %v; want %v", r.Trace, want)
+	}
+	for i := range want {
+		if r.Trace[i] != want[i] {
+			t.Fatalf("Trace = %v; want %v", r.Trace, want)
+		}
+	}
+	var b bytes.Buffer
+	if err := Replay(&b, []int{3, 1, 2}, r.Trace); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 3 || !strings.HasSuffix(lines[2], "[1 2* 3*]") {
+		t.Errorf("Replay =\n%s", b.String())
+	}
+}
+
+func TestRecorderDupSortable(t *testing.T) {
+	r := NewRecorder(false)
+	s := dupInts(Generate("random", 100, 1))
+	dupsort.BottomUpMergeSort(r.DupSortable(s))
+	if r.New != 1 || r.Allocated != 100 {
+		t.Errorf("BottomUpMergeSort: %d calls to New for %d elements; want 1, 100", r.New, r.Allocated)
+	}
+	if r.Less == 0 || r.At == 0 || r.Set == 0 {
+		t.Errorf("BottomUpMergeSort counts not recorded: %+v", r)
+	}
+	for i := 1; i < len(s); i++ {
+		if s[i] < s[i-1] {
+			t.Fatalf("wrapped collection not sorted")
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	for _, dist := range Distributions {
+		if s := Generate(dist, 10, 1); len(s) != 10 {
+			t.Errorf("Generate(%s) = %v", dist, s)
+		}
+	}
+	if s := Generate("unknown", 0, 1); s != nil {
+		t.Errorf("Generate of an unknown distribution = %v; want nil", s)
+	}
+}
+
+func TestReport(t *testing.T) {
+	var b bytes.Buffer
+	if err := Report(&b, 64); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, name := range []string{"InsertionSort", "QuickSort", "HeapSort", "dupsort.TimSort", "organ-pipe", "few-unique"} {
+		if !strings.Contains(out, name) {
+			t.Errorf("Report is missing %s:\n%s", name, out)
+		}
+	}
+	if rows := strings.Count(out, "\n"); rows != 1+len(Distributions)*(len(sortables)+len(dupSortables)) {
+		t.Errorf("Report has %d lines", rows)
+	}
+}
@@ -0,0 +1,100 @@
+package instrument
+
+import (
+	"fmt"
+	"github.com/twmb/algoimpl/go/sort"
+	"github.com/twmb/algoimpl/go/sort/dupsort"
+	"io"
+	"math/rand"
+	"text/tabwriter"
+)
+
+// Distributions are the kinds of input Generate can make, in report order.
+var Distributions = []string{"random", "sorted", "reversed", "few-unique", "organ-pipe"}
+
+// Generate returns n ints of the named distribution, or nil for an unknown
+// name. Random distributions are drawn from a source seeded with seed.
+func Generate(distribution string, n int, seed int64) []int {
+	var value func(r *rand.Rand, i int) int
+	switch distribution {
+	case "random":
+		value = func(r *rand.Rand, i int) int { return r.Intn(n) }
+	case "sorted":
+		value = func(r *rand.Rand, i int) int { return i }
+	case "reversed":
+		value = func(r *rand.Rand, i int) int { return n - i }
+	case "few-unique":
+		value = func(r *rand.Rand, i int) int { return r.Intn(4) }
+	case "organ-pipe": // up then back down
+		value = func(r *rand.Rand, i int) int { return min(i, n-1-i) }
+	default:
+		return nil
+	}
+	r := rand.New(rand.NewSource(seed))
+	s := make([]int, n)
+	for i := range s {
+		s[i] = value(r, i)
+	}
+	return s
+}
+
+type ints []int
+
+func (p ints) Len() int           { return len(p) }
+func (p ints) Less(i, j int) bool { return p[i] < p[j] }
+func (p ints) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+type dupInts []int
+
+func (p dupInts) Len() int                      { return len(p) }
+func (p dupInts) Less(a, b interface{}) bool    { return a.(int) < b.(int) }
+func (p dupInts) At(i int) interface{}          { return p[i] }
+func (p dupInts) Set(i int, v interface{})      { p[i] = v.(int) }
+func (p dupInts) New(i int) dupsort.DupSortable { return make(dupInts, i) }
+
+// The algorithms in a report.
+var (
+	sortables = []struct {
+		name string
+		sort func(sort.Sortable)
+	}{
+		{"InsertionSort", sort.InsertionSort},
+		{"QuickSort", sort.QuickSort},
+		{"HeapSort", sort.HeapSort},
+		{"IntroSort", sort.IntroSort},
+		{"ShellSort", sort.ShellSort},
+		{"StableSort", sort.StableSort},
+	}
+	dupSortables = []struct {
+		name string
+		sort func(dupsort.DupSortable)
+	}{
+		{"dupsort.MergeSort", func(s dupsort.DupSortable) { dupsort.MergeSort(s, 0, s.Len()) }},
+		{"dupsort.BottomUpMergeSort", dupsort.BottomUpMergeSort},
+		{"dupsort.NaturalMergeSort", dupsort.NaturalMergeSort},
+		{"dupsort.TimSort", dupsort.TimSort},
+	}
+)
+
+// Report sorts n ints of every distribution with every algorithm in
+// sort and dupsort and writes a table of the operations each made to w.
+// The sorts that are O(n^2) on some inputs make n large reports slow.
+func Report(w io.Writer, n int) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "algorithm\tinput\tless\tswap\tat\tset\tnew\tallocated\t")
+	r := NewRecorder(false)
+	for _, dist := range Distributions {
+		in := Generate(dist, n, 1)
+		for _, a := range sortables {
+			r.Reset()
+			a.sort(r.Sortable(append(ints(nil), in...)))
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t-\t-\t-\t-\t\n", a.name, dist, r.Less, r.Swap)
+		}
+		for _, a := range dupSortables {
+			r.Reset()
+			a.sort(r.DupSortable(append(dupInts(nil), in...)))
+			fmt.Fprintf(tw, "%s\t%s\t%d\t-\t%d\t%d\t%d\t%d\t\n", a.name, dist, r.Less, r.At, r.Set, r.New, r.Allocated)
+		}
+	}
+	return tw.Flush()
+}
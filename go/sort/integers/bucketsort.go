@@ -0,0 +1,50 @@
+package integers
+
+import (
+	"errors"
+)
+
+// Sorts me, whose values must all be in [0, 1), in place with bucket
+// sort: every value goes into one of len(me) equal width buckets, and
+// the buckets are insertion sorted. Runs in O(n) expected time if the
+// values are spread uniformly and O(n^2) if they all share a bucket.
+// Returns an error and leaves me unchanged if a value is out of range.
+func BucketSort(me []float64) error {
+	for _, v := range me {
+		if !(v >= 0 && v < 1) { // also catches NaN
+			return errors.New("BucketSort: value out of [0, 1)")
+		}
+	}
+	n := len(me)
+	bucket := func(v float64) int {
+		return min(int(v*float64(n)), n-1) // v*n can round up to n
+	}
+	// counting the bucket sizes first keeps every bucket in one slice
+	starts := make([]int, n+1)
+	for _, v := range me {
+		starts[bucket(v)+1]++
+	}
+	for i := 1; i <= n; i++ {
+		starts[i] += starts[i-1]
+	}
+	sorted := make([]float64, n)
+	next := append([]int(nil), starts[:n]...)
+	for _, v := range me {
+		b := bucket(v)
+		sorted[next[b]] = v
+		next[b]++
+	}
+	for b := 0; b < n; b++ {
+		bucket := sorted[starts[b]:starts[b+1]]
+		for j := 1; j < len(bucket); j++ {
+			v := bucket[j]
+			i := j
+			for ; i > 0 && v < bucket[i-1]; i-- {
+				bucket[i] = bucket[i-1]
+			}
+			bucket[i] = v
+		}
+	}
+	copy(me, sorted)
+	return nil
+}
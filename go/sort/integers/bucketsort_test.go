@@ -0,0 +1,40 @@
+package integers
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestBucketSort(t *testing.T) {
+	r := rand.New(rand.NewSource(41))
+	for _, n := range []int{0, 1, 2, 1000} {
+		in := make([]float64, n)
+		for i := range in {
+			in[i] = r.Float64()
+		}
+		if n > 1 {
+			in[0] = math.Nextafter(1, 0)
+		}
+		want := append([]float64(nil), in...)
+		sort.Float64s(want)
+		if err := BucketSort(in); err != nil {
+			t.Fatalf("BucketSort(n=%d): %v", n, err)
+		}
+		for i := range want {
+			if in[i] != want[i] {
+				t.Fatalf("BucketSort(n=%d): [%d] = %v; want %v", n, i, in[i], want[i])
+			}
+		}
+	}
+	for _, bad := range []float64{-0.1, 1, math.NaN(), math.Inf(1)} {
+		in := []float64{0.5, bad, 0.25}
+		if err := BucketSort(in); err == nil {
+			t.Errorf("BucketSort accepted %v", bad)
+		}
+		if in[0] != 0.5 || in[2] != 0.25 {
+			t.Errorf("BucketSort modified its input on error: %v", in)
+		}
+	}
+}
@@ -1,5 +1,9 @@
 package integers
 
+// Key ranges this much wider than the slice are radix sorted by
+// CountingSortFunc rather than counted.
+const sparseSpan = 1 << 16
+
 // Runs counting sort on a slice of ints with the minVal being the minimum value
 // in the slice and maxVal being the maximum.
 // Has O(n + maxVal - minVal) time complexity, where n is the length of the slice.
@@ -18,3 +22,49 @@ func CountingSort(me []int, minVal, maxVal int) (sorted []int) {
 	}
 	return
 }
+
+// Runs counting sort on a slice of any type, ordering by the int that
+// key returns for each element. The smallest and largest keys are found
+// with an extra pass, so they need not be known. The sort is stable,
+// which makes it useful for records. Has O(n + maxKey - minKey) time
+// complexity when keys are dense. If the keys are spread out so far that
+// the counts would be larger than the slice plus sparseSpan, it instead
+// radix sorts the keys in O(8n), so wide 64-bit keys are fine.
+func CountingSortFunc[T any](me []T, key func(T) int) (sorted []T) {
+	sorted = make([]T, len(me))
+	if len(me) == 0 {
+		return
+	}
+	keys := make([]int, len(me))
+	minKey, maxKey := key(me[0]), key(me[0])
+	for i := range me {
+		keys[i] = key(me[i])
+		minKey = min(minKey, keys[i])
+		maxKey = max(maxKey, keys[i])
+	}
+	span := uint64(maxKey) - uint64(minKey) // cannot overflow, unlike maxKey - minKey
+	if span >= uint64(len(me))+sparseSpan {
+		order := make([]int, len(me))
+		for i := range order {
+			order[i] = i
+		}
+		lsdRadix(order, func(i int) uint64 { return intKey(keys[i]) }) // stable
+		for i, j := range order {
+			sorted[i] = me[j]
+		}
+		return
+	}
+	counts := make([]int, span+1)
+	for _, k := range keys {
+		counts[uint64(k)-uint64(minKey)]++
+	}
+	for i := 1; i < len(counts); i++ {
+		counts[i] += counts[i-1]
+	}
+	for i := len(me) - 1; i >= 0; i-- { // backwards for stability
+		k := uint64(keys[i]) - uint64(minKey)
+		counts[k]--
+		sorted[counts[k]] = me[i]
+	}
+	return
+}
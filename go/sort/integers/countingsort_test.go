@@ -1,6 +1,9 @@
 package integers
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestCountingSort(t *testing.T) {
 	tests := []struct {
@@ -26,3 +29,41 @@ func TestCountingSort(t *testing.T) {
 		}
 	}
 }
+
+func TestCountingSortFunc(t *testing.T) {
+	type record struct {
+		age  int
+		name string
+	}
+	in := []record{{30, "a"}, {-2, "b"}, {30, "c"}, {7, "d"}, {-2, "e"}}
+	want := []record{{-2, "b"}, {-2, "e"}, {7, "d"}, {30, "a"}, {30, "c"}}
+	got := CountingSortFunc(in, func(r record) int { return r.age })
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("CountingSortFunc = %v; want %v", got, want)
+		}
+	}
+	if got := CountingSortFunc([]int{}, func(v int) int { return v }); len(got) != 0 {
+		t.Errorf("CountingSortFunc of empty slice = %v", got)
+	}
+}
+
+func TestCountingSortFuncWideKeys(t *testing.T) {
+	type record struct {
+		id  int
+		tag int
+	}
+	for _, in := range [][]record{
+		{{math.MaxInt, 0}, {math.MinInt, 1}, {0, 2}, {math.MinInt, 3}, {math.MaxInt, 4}},
+		{{1 << 40, 0}, {-5, 1}, {1 << 40, 2}, {3, 3}},
+		{{math.MaxInt, 0}, {math.MaxInt - 2, 1}, {math.MaxInt, 2}}, // narrow, near the top
+	} {
+		got := CountingSortFunc(in, func(r record) int { return r.id })
+		for i := 1; i < len(got); i++ {
+			if got[i-1].id > got[i].id || got[i-1].id == got[i].id && got[i-1].tag > got[i].tag {
+				t.Errorf("CountingSortFunc(%v) = %v; not stably sorted", in, got)
+				break
+			}
+		}
+	}
+}
@@ -1,43 +1,117 @@
 // Implements merge sort on []ints.
-// Knowing that the underlying type is a slice allows for using channels.
-// This also allows for using goroutines.
+// Knowing that the underlying type is a slice allows for sorting halves
+// of it on different goroutines and streaming the result over a channel.
 package integers
 
-func mergeCombine(lch, rch <-chan int, tch chan<- int) {
-	lv, lopen := <-lch
-	rv, ropen := <-rch
-	for lopen && ropen {
-		if lv < rv {
-			tch <- lv
-			lv, lopen = <-lch
+import (
+	"runtime"
+)
+
+// DefaultCutoff is the length below which ParallelSort stops handing
+// halves to other goroutines when it is given a cutoff of 0.
+// Smaller ranges sort faster on one goroutine than it takes to start another.
+const DefaultCutoff = 1 << 13
+
+// Ranges this short are insertion sorted.
+const insertionCutoff = 24
+
+func insertionSort(me []int) {
+	for j := 1; j < len(me); j++ {
+		v := me[j]
+		i := j
+		for ; i > 0 && v < me[i-1]; i-- {
+			me[i] = me[i-1]
+		}
+		me[i] = v
+	}
+}
+
+// Merges the sorted l and r into to, which must have room for both.
+func merge(l, r, to []int) {
+	li, ri, ti := 0, 0, 0
+	for ; li < len(l) && ri < len(r); ti++ {
+		if r[ri] < l[li] {
+			to[ti] = r[ri]
+			ri++
 		} else {
-			tch <- rv
-			rv, ropen = <-rch
+			to[ti] = l[li]
+			li++
 		}
 	}
-	for lopen {
-		tch <- lv
-		lv, lopen = <-lch
+	ti += copy(to[ti:], l[li:])
+	copy(to[ti:], r[ri:])
+}
+
+// Sorts me using buf, which is as long as me, as scratch space.
+// Ranges longer than cutoff sort their left half on a new goroutine
+// if one of the tokens in sem is free.
+func mergeSort(me, buf []int, sem chan struct{}, cutoff int) {
+	if len(me) <= insertionCutoff {
+		insertionSort(me)
+		return
 	}
-	for ropen {
-		tch <- rv
-		rv, ropen = <-rch
+	mid := len(me) / 2
+	spawned := false
+	if len(me) > cutoff {
+		select {
+		case sem <- struct{}{}:
+			spawned = true
+		default:
+		}
 	}
-	close(tch)
+	if spawned {
+		done := make(chan struct{})
+		go func() {
+			mergeSort(me[:mid], buf[:mid], sem, cutoff)
+			<-sem
+			close(done)
+		}()
+		mergeSort(me[mid:], buf[mid:], sem, cutoff)
+		<-done
+	} else {
+		mergeSort(me[:mid], buf[:mid], sem, cutoff)
+		mergeSort(me[mid:], buf[mid:], sem, cutoff)
+	}
+	if me[mid-1] <= me[mid] { // already in order
+		return
+	}
+	copy(buf, me)
+	merge(buf[:mid], buf[mid:], me)
+}
+
+// ParallelSort sorts me in place with a merge sort that runs on at most
+// workers goroutines at once. Ranges shorter than cutoff are sorted
+// sequentially. A workers of 0 uses GOMAXPROCS and a cutoff of 0 uses
+// DefaultCutoff. It allocates one scratch slice as long as me.
+func ParallelSort(me []int, workers, cutoff int) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if cutoff <= 0 {
+		cutoff = DefaultCutoff
+	}
+	// the calling goroutine is a worker too, so it needs no token
+	sem := make(chan struct{}, workers-1)
+	mergeSort(me, make([]int, len(me)), sem, cutoff)
 }
 
 // This function takes a slice to be sorted, a range to sort
-// and a channel to send in-order ints to.
+// and a channel to send in-order ints to. The channel is closed
+// once every int has been sent. me is not modified: the whole range is
+// copied and sorted with ParallelSort before the first int is sent,
+// so it takes O(to - from) extra memory and nothing arrives early.
+// It uses the default worker count and cutoff; see MergeSortWith.
 func MergeSort(me []int, from, to int, tch chan<- int) {
-	if from < to-1 {
-		lch, rch := make(chan int), make(chan int)
-		go MergeSort(me, from, (from+to)/2, lch)
-		go MergeSort(me, (from+to)/2, to, rch)
-		mergeCombine(lch, rch, tch)
-	} else {
-		for i := from; i < to; i++ {
-			tch <- me[i]
-		}
-		close(tch)
+	MergeSortWith(me, from, to, tch, 0, 0)
+}
+
+// MergeSortWith is MergeSort with the workers and cutoff passed on to
+// ParallelSort, where 0 picks the default for either.
+func MergeSortWith(me []int, from, to int, tch chan<- int, workers, cutoff int) {
+	sorted := append([]int(nil), me[from:to]...)
+	ParallelSort(sorted, workers, cutoff)
+	for _, v := range sorted {
+		tch <- v
 	}
+	close(tch)
 }
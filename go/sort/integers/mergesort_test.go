@@ -1,6 +1,10 @@
 package integers
 
-import "testing"
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
 
 func TestMergeSort(t *testing.T) {
 	tests := []struct {
@@ -30,3 +34,92 @@ func TestMergeSort(t *testing.T) {
 		}
 	}
 }
+
+func TestParallelSort(t *testing.T) {
+	r := rand.New(rand.NewSource(40))
+	for _, n := range []int{0, 1, 2, 24, 25, 1000, 100000} {
+		for _, workers := range []int{0, 1, 4} {
+			for _, cutoff := range []int{0, 1, 500} {
+				in := make([]int, n)
+				for i := range in {
+					in[i] = r.Intn(n+1) - n/2
+				}
+				want := append([]int(nil), in...)
+				sort.Ints(want)
+				ParallelSort(in, workers, cutoff)
+				for i := range want {
+					if in[i] != want[i] {
+						t.Fatalf("ParallelSort(n=%d, workers=%d, cutoff=%d): [%d] = %v; want %v", n, workers, cutoff, i, in[i], want[i])
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestMergeSortWith(t *testing.T) {
+	in := rand.New(rand.NewSource(40)).Perm(5000)
+	for _, config := range [][2]int{{1, 0}, {4, 1}, {3, 100}, {0, 0}} {
+		tch := make(chan int)
+		go MergeSortWith(in, 0, len(in), tch, config[0], config[1])
+		i := 0
+		for v := range tch {
+			if v != i {
+				t.Fatalf("MergeSortWith(workers=%d, cutoff=%d) sent %d at %d", config[0], config[1], v, i)
+			}
+			i++
+		}
+		if i != len(in) {
+			t.Errorf("MergeSortWith(workers=%d, cutoff=%d) sent %d ints; want %d", config[0], config[1], i, len(in))
+		}
+	}
+}
+
+func TestMergeSortRange(t *testing.T) {
+	in := []int{9, 5, 3, 7, 1, 8}
+	tch := make(chan int)
+	go MergeSort(in, 1, 5, tch)
+	var got []int
+	for v := range tch {
+		got = append(got, v)
+	}
+	want := []int{1, 3, 5, 7}
+	if len(got) != len(want) {
+		t.Fatalf("MergeSort range = %v; want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("MergeSort range = %v; want %v", got, want)
+		}
+	}
+	if in[0] != 9 || in[1] != 5 || in[4] != 1 {
+		t.Errorf("MergeSort modified its input: %v", in)
+	}
+}
+
+func benchmarkInts(b *testing.B, sortInts func([]int)) {
+	in := rand.New(rand.NewSource(40)).Perm(1 << 20)
+	s := make([]int, len(in))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(s, in)
+		sortInts(s)
+	}
+}
+
+func BenchmarkSortInts(b *testing.B) { benchmarkInts(b, sort.Ints) }
+func BenchmarkParallelSort1(b *testing.B) {
+	benchmarkInts(b, func(s []int) { ParallelSort(s, 1, 0) })
+}
+func BenchmarkParallelSort(b *testing.B) {
+	benchmarkInts(b, func(s []int) { ParallelSort(s, 0, 0) })
+}
+func BenchmarkMergeSortStream(b *testing.B) {
+	benchmarkInts(b, func(s []int) {
+		tch := make(chan int, 1024)
+		go MergeSort(s, 0, len(s), tch)
+		for range tch {
+		}
+	})
+}
@@ -0,0 +1,209 @@
+package integers
+
+// Radix sorts order keys one byte (digit) at a time with counting sort,
+// so they run in O(w * n) time for w byte keys no matter how far apart
+// the keys are, unlike CountingSort.
+
+// Maps an int to a uint64 with the same ordering by flipping the sign bit.
+func intKey(v int) uint64 {
+	return uint64(v) ^ 1<<63
+}
+
+func uint64Key(v uint64) uint64 {
+	return v
+}
+
+// Sorts me by key, least significant byte first, with a scratch slice.
+// Passes where every key has the same byte are skipped.
+func lsdRadix[T any](me []T, key func(T) uint64) {
+	if len(me) < 2 {
+		return
+	}
+	src, dst := me, make([]T, len(me))
+	for shift := 0; shift < 64; shift += 8 {
+		var counts [256]int
+		for _, v := range src {
+			counts[byte(key(v)>>shift)]++
+		}
+		if counts[byte(key(src[0])>>shift)] == len(src) {
+			continue
+		}
+		pos := 0
+		for i, c := range counts {
+			counts[i] = pos
+			pos += c
+		}
+		for _, v := range src {
+			d := byte(key(v) >> shift)
+			dst[counts[d]] = v
+			counts[d]++
+		}
+		src, dst = dst, src
+	}
+	if &src[0] != &me[0] {
+		copy(me, src)
+	}
+}
+
+// Sorts me by key in place, most significant byte first. Each pass moves
+// the elements into their buckets by following cycles of swaps
+// (American flag sort) and then recurses into every bucket.
+func msdRadix[T any](me []T, key func(T) uint64, shift int) {
+	if len(me) <= insertionCutoff {
+		for j := 1; j < len(me); j++ {
+			for i := j; i > 0 && key(me[i]) < key(me[i-1]); i-- {
+				me[i], me[i-1] = me[i-1], me[i]
+			}
+		}
+		return
+	}
+	var counts, heads, tails [256]int
+	for _, v := range me {
+		counts[byte(key(v)>>shift)]++
+	}
+	pos := 0
+	for i, c := range counts {
+		heads[i] = pos
+		pos += c
+		tails[i] = pos
+	}
+	for b := range heads {
+		for heads[b] < tails[b] {
+			d := byte(key(me[heads[b]]) >> shift)
+			if int(d) == b {
+				heads[b]++
+				continue
+			}
+			me[heads[b]], me[heads[d]] = me[heads[d]], me[heads[b]]
+			heads[d]++
+		}
+	}
+	if shift == 0 {
+		return
+	}
+	start := 0
+	for _, c := range counts {
+		if c > 1 {
+			msdRadix(me[start:start+c], key, shift-8)
+		}
+		start += c
+	}
+}
+
+// Sorts me in place with a least significant digit radix sort.
+// It allocates one scratch slice as long as me and is stable.
+func LSDRadixSort(me []int) {
+	lsdRadix(me, intKey)
+}
+
+// Sorts me in place with a least significant digit radix sort.
+// It allocates one scratch slice as long as me and is stable.
+func LSDRadixSortUint64(me []uint64) {
+	lsdRadix(me, uint64Key)
+}
+
+// Sorts me in place with a most significant digit radix sort.
+// It needs no scratch space and stops early on buckets that are small,
+// which makes it faster than LSDRadixSort when keys differ in their
+// high bytes, but it is not stable.
+func MSDRadixSort(me []int) {
+	msdRadix(me, intKey, 56)
+}
+
+// Sorts me in place with a most significant digit radix sort.
+// See MSDRadixSort.
+func MSDRadixSortUint64(me []uint64) {
+	msdRadix(me, uint64Key, 56)
+}
+
+// Returns the bucket of the byte at d in s: 0 if s is shorter than d,
+// so that a string sorts before the strings it is a prefix of, else the byte plus 1.
+func charAt(s string, d int) int {
+	if d < len(s) {
+		return int(s[d]) + 1
+	}
+	return 0
+}
+
+// Sorts me in place with a least significant digit radix sort, padding
+// shorter strings at the end so they sort before longer strings that
+// they prefix. Runs in O(w * n) time for strings of at most w bytes, so
+// it suits many keys of similar, short length. It is stable.
+func LSDRadixSortStrings(me []string) {
+	if len(me) < 2 {
+		return
+	}
+	w := 0
+	for _, s := range me {
+		w = max(w, len(s))
+	}
+	src, dst := me, make([]string, len(me))
+	for d := w - 1; d >= 0; d-- {
+		var counts [257]int
+		for _, s := range src {
+			counts[charAt(s, d)]++
+		}
+		pos := 0
+		for i, c := range counts {
+			counts[i] = pos
+			pos += c
+		}
+		for _, s := range src {
+			c := charAt(s, d)
+			dst[counts[c]] = s
+			counts[c]++
+		}
+		src, dst = dst, src
+	}
+	if &src[0] != &me[0] {
+		copy(me, src)
+	}
+}
+
+// Sorts strings that share their first d bytes, starting the radix sort at byte d.
+func msdStrings(me []string, d int) {
+	if len(me) <= insertionCutoff {
+		for j := 1; j < len(me); j++ {
+			for i := j; i > 0 && me[i][d:] < me[i-1][d:]; i-- {
+				me[i], me[i-1] = me[i-1], me[i]
+			}
+		}
+		return
+	}
+	var counts, heads, tails [257]int
+	for _, s := range me {
+		counts[charAt(s, d)]++
+	}
+	pos := 0
+	for i, c := range counts {
+		heads[i] = pos
+		pos += c
+		tails[i] = pos
+	}
+	for b := range heads {
+		for heads[b] < tails[b] {
+			c := charAt(me[heads[b]], d)
+			if c == b {
+				heads[b]++
+				continue
+			}
+			me[heads[b]], me[heads[c]] = me[heads[c]], me[heads[b]]
+			heads[c]++
+		}
+	}
+	start := counts[0] // strings that ended are all equal
+	for _, c := range counts[1:] {
+		if c > 1 {
+			msdStrings(me[start:start+c], d+1)
+		}
+		start += c
+	}
+}
+
+// Sorts me in place with a most significant digit radix sort.
+// It only looks at as many bytes of each string as it takes to tell it
+// apart from the others, so it does well on long strings with short
+// distinct prefixes. It needs no scratch space but is not stable.
+func MSDRadixSortStrings(me []string) {
+	msdStrings(me, 0)
+}
@@ -0,0 +1,103 @@
+package integers
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func randomInts(r *rand.Rand, n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		switch r.Intn(4) {
+		case 0:
+			s[i] = r.Intn(100) - 50 // small, many duplicates
+		case 1:
+			s[i] = int(r.Uint64())
+		default:
+			s[i] = r.Intn(1<<20) - 1<<19
+		}
+	}
+	if n > 2 {
+		s[0], s[1] = math.MinInt, math.MaxInt
+	}
+	return s
+}
+
+func TestRadixSortInts(t *testing.T) {
+	r := rand.New(rand.NewSource(41))
+	for _, n := range []int{0, 1, 2, 24, 25, 1000, 50000} {
+		for name, radix := range map[string]func([]int){"LSDRadixSort": LSDRadixSort, "MSDRadixSort": MSDRadixSort} {
+			in := randomInts(r, n)
+			want := append([]int(nil), in...)
+			sort.Ints(want)
+			radix(in)
+			for i := range want {
+				if in[i] != want[i] {
+					t.Fatalf("%s(n=%d): [%d] = %v; want %v", name, n, i, in[i], want[i])
+				}
+			}
+		}
+	}
+}
+
+func TestRadixSortUint64(t *testing.T) {
+	r := rand.New(rand.NewSource(41))
+	for _, n := range []int{0, 1, 1000, 50000} {
+		for name, radix := range map[string]func([]uint64){"LSDRadixSortUint64": LSDRadixSortUint64, "MSDRadixSortUint64": MSDRadixSortUint64} {
+			in := make([]uint64, n)
+			for i := range in {
+				in[i] = r.Uint64() >> (r.Intn(8) * 8)
+			}
+			want := append([]uint64(nil), in...)
+			sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+			radix(in)
+			for i := range want {
+				if in[i] != want[i] {
+					t.Fatalf("%s(n=%d): [%d] = %v; want %v", name, n, i, in[i], want[i])
+				}
+			}
+		}
+	}
+}
+
+func TestRadixSortStrings(t *testing.T) {
+	r := rand.New(rand.NewSource(41))
+	for _, n := range []int{0, 1, 24, 25, 1000, 20000} {
+		for name, radix := range map[string]func([]string){"LSDRadixSortStrings": LSDRadixSortStrings, "MSDRadixSortStrings": MSDRadixSortStrings} {
+			in := make([]string, n)
+			for i := range in {
+				var b strings.Builder
+				for l := r.Intn(8); l > 0; l-- {
+					b.WriteByte("ab\x00\xff"[r.Intn(4)]) // prefixes, NULs and high bytes
+				}
+				in[i] = b.String()
+			}
+			want := append([]string(nil), in...)
+			sort.Strings(want)
+			radix(in)
+			for i := range want {
+				if in[i] != want[i] {
+					t.Fatalf("%s(n=%d): [%d] = %q; want %q", name, n, i, in[i], want[i])
+				}
+			}
+		}
+	}
+}
+
+func benchmarkRadix(b *testing.B, sortInts func([]int)) {
+	in := randomInts(rand.New(rand.NewSource(41)), 1<<20)
+	s := make([]int, len(in))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(s, in)
+		sortInts(s)
+	}
+}
+
+func BenchmarkRadixSortInts(b *testing.B) { benchmarkRadix(b, sort.Ints) }
+func BenchmarkLSDRadixSort(b *testing.B)  { benchmarkRadix(b, LSDRadixSort) }
+func BenchmarkMSDRadixSort(b *testing.B)  { benchmarkRadix(b, MSDRadixSort) }
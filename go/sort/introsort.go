@@ -0,0 +1,60 @@
+package sort
+
+// Ranges of more than this many elements pick their pivot with Tukey's ninther.
+const nintherCutoff = 40
+
+// Returns the index of a pivot for the elements in [from, to).
+func choosePivot(s Sortable, from, to int) int {
+	n := to - from
+	mid := from + n/2
+	if n > nintherCutoff {
+		// median of the medians of three evenly spaced triples
+		e := n / 8
+		return medianOfThree(s,
+			medianOfThree(s, from, from+e, from+2*e),
+			medianOfThree(s, mid-e, mid, mid+e),
+			medianOfThree(s, to-1-2*e, to-1-e, to-1))
+	}
+	return medianOfThree(s, from, mid, to-1)
+}
+
+func introSort(s Sortable, from, to, depth int, small smallSorter) {
+	for to-from > small.cutoff {
+		if depth == 0 {
+			HeapSortRange(s, from, to)
+			return
+		}
+		depth--
+		p := partition(s, from, to, choosePivot(s, from, to))
+		if p-from < to-p-1 {
+			introSort(s, from, p, depth, small)
+			from = p + 1
+		} else {
+			introSort(s, p+1, to, depth, small)
+			to = p
+		}
+	}
+	small.sort(s, from, to)
+}
+
+// Returns the depth at which introsort gives up on quicksort: 2*ceil(lg(n+1)).
+func maxDepth(n int) int {
+	depth := 0
+	for i := n; i > 0; i >>= 1 {
+		depth++
+	}
+	return 2 * depth
+}
+
+// Runs introsort on a Sortable collection. Introsort is quicksort with
+// ninther pivots that switches to heapsort once the recursion gets too
+// deep and to insertion sort for small ranges, so it runs in
+// O(n * lg n) time on every input. It is not stable.
+func IntroSort(s Sortable) {
+	IntroSortRange(s, 0, s.Len())
+}
+
+// Runs introsort on the elements in [from, to) of a Sortable collection.
+func IntroSortRange(s Sortable, from, to int) {
+	introSort(s, from, to, maxDepth(to-from), smallRanges)
+}
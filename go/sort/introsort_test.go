@@ -0,0 +1,49 @@
+package sort
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIntroSort(t *testing.T) {
+	s := Ints(append([]int(nil), ints...))
+	IntroSort(s)
+	for i := 1; i < len(s); i++ {
+		if s[i] < s[i-1] {
+			t.Fatalf("IntroSort result not sorted: %v", s)
+		}
+	}
+}
+
+func TestIntroSortRange(t *testing.T) {
+	testSortRange(t, "IntroSortRange", IntroSortRange)
+}
+
+// Introsort must stay O(n lg n) on inputs that are bad for quicksort.
+func TestIntroSortComparisons(t *testing.T) {
+	const n = 1 << 12
+	limit := int(3 * n * math.Log2(n))
+	for input, in := range adversarialInputs(n) {
+		c := &countingInts{Ints: append(Ints(nil), in...)}
+		IntroSort(c)
+		if c.less > limit {
+			t.Errorf("IntroSort(%s) made %v comparisons; want at most %v", input, c.less, limit)
+		}
+	}
+}
+
+func benchmarkSort(b *testing.B, input string, sort func(Sortable)) {
+	in := adversarialInputs(1 << 14)[input]
+	s := make(Ints, len(in))
+	for i := 0; i < b.N; i++ {
+		copy(s, in)
+		sort(s)
+	}
+}
+
+func BenchmarkIntroSortRandom(b *testing.B)   { benchmarkSort(b, "random", IntroSort) }
+func BenchmarkQuickSortRandom(b *testing.B)   { benchmarkSort(b, "random", QuickSort) }
+func BenchmarkHeapSortRandom(b *testing.B)    { benchmarkSort(b, "random", HeapSort) }
+func BenchmarkShellSortRandom(b *testing.B)   { benchmarkSort(b, "random", ShellSort) }
+func BenchmarkIntroSortM3Killer(b *testing.B) { benchmarkSort(b, "m3killer", IntroSort) }
+func BenchmarkQuickSortM3Killer(b *testing.B) { benchmarkSort(b, "m3killer", QuickSort) }
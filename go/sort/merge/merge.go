@@ -0,0 +1,102 @@
+// Package merge merges any number of sorted sources into one sorted
+// sequence. The sources are merged with a heap holding the next value
+// of each, so merging n values from k sources takes O(n lg k) time.
+//
+// Values that are equal under less come out in source order, and in
+// their order within a source, so merging stably sorted shards gives
+// a stably sorted whole.
+package merge
+
+import (
+	"github.com/twmb/algoimpl/go/tree/heap"
+	"iter"
+)
+
+// The next value of a source waiting to be merged.
+type head[T any] struct {
+	v      T
+	source int
+}
+
+// Merges the values returned by every next func, calling yield on each
+// in order until it returns false. next returns false once it is empty.
+func merge[T any](less func(a, b T) bool, sources []func() (T, bool), yield func(T) bool) {
+	h := heap.New(func(a, b head[T]) bool {
+		if less(a.v, b.v) {
+			return true
+		}
+		return !less(b.v, a.v) && a.source < b.source
+	})
+	for i, next := range sources {
+		if v, ok := next(); ok {
+			h.Push(head[T]{v, i})
+		}
+	}
+	for h.Len() > 0 {
+		top := h.Peek()
+		if !yield(top.v) {
+			return
+		}
+		if v, ok := sources[top.source](); ok {
+			h.Replace(head[T]{v, top.source})
+		} else {
+			h.Pop()
+		}
+	}
+}
+
+// Slices merges sorted slices, each sorted by less, into a new sorted slice.
+func Slices[T any](less func(a, b T) bool, slices [][]T) []T {
+	n := 0
+	sources := make([]func() (T, bool), len(slices))
+	for i, s := range slices {
+		n += len(s)
+		sources[i] = func() (v T, ok bool) {
+			if len(s) == 0 {
+				return v, false
+			}
+			v, s = s[0], s[1:]
+			return v, true
+		}
+	}
+	merged := make([]T, 0, n)
+	merge(less, sources, func(v T) bool {
+		merged = append(merged, v)
+		return true
+	})
+	return merged
+}
+
+// Seqs returns a sequence of the values of every seq, each sorted by less,
+// in sorted order. Values are pulled from the seqs only as they are needed,
+// so the seqs may be unbounded. Each seq is started once per iteration
+// and stopped when the iteration ends.
+func Seqs[T any](less func(a, b T) bool, seqs []iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		sources := make([]func() (T, bool), len(seqs))
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			sources[i] = next
+		}
+		merge(less, sources, yield)
+	}
+}
+
+// Channels returns a sequence of the values received from every channel,
+// each sorted by less, in sorted order. A channel is done once it is
+// closed; the sequence ends once every channel is done. Values are
+// received only as they are needed, so if the iteration ends early the
+// senders are left blocked and must be released some other way.
+func Channels[T any](less func(a, b T) bool, chans []<-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		sources := make([]func() (T, bool), len(chans))
+		for i, ch := range chans {
+			sources[i] = func() (T, bool) {
+				v, ok := <-ch
+				return v, ok
+			}
+		}
+		merge(less, sources, yield)
+	}
+}
@@ -0,0 +1,128 @@
+package merge
+
+import (
+	"iter"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// A key and where it came from. Only keys are compared.
+type tagged struct {
+	key, source, index int
+}
+
+func lessTagged(a, b tagged) bool { return a.key < b.key }
+
+// Returns k sorted shards of random tagged keys with many duplicates.
+func shards(r *rand.Rand, k int) [][]tagged {
+	s := make([][]tagged, k)
+	for i := range s {
+		keys := make([]int, r.Intn(50))
+		for j := range keys {
+			keys[j] = r.Intn(20)
+		}
+		sort.Ints(keys)
+		for j, key := range keys {
+			s[i] = append(s[i], tagged{key, i, j})
+		}
+	}
+	return s
+}
+
+// Checks merged holds every value of s in stable sorted order.
+func check(t *testing.T, name string, s [][]tagged, merged []tagged) {
+	n := 0
+	for _, shard := range s {
+		n += len(shard)
+	}
+	if len(merged) != n {
+		t.Fatalf("%s merged %d values; want %d", name, len(merged), n)
+	}
+	for i := 1; i < len(merged); i++ {
+		a, b := merged[i-1], merged[i]
+		if b.key < a.key || b.key == a.key && (b.source < a.source || b.source == a.source && b.index < a.index) {
+			t.Fatalf("%s not stably sorted at %d: %v after %v", name, i, b, a)
+		}
+	}
+}
+
+func TestSlices(t *testing.T) {
+	r := rand.New(rand.NewSource(43))
+	for _, k := range []int{0, 1, 2, 7, 64} {
+		s := shards(r, k)
+		check(t, "Slices", s, Slices(lessTagged, s))
+	}
+}
+
+func TestSeqs(t *testing.T) {
+	r := rand.New(rand.NewSource(43))
+	for _, k := range []int{0, 1, 2, 7, 64} {
+		s := shards(r, k)
+		seqs := make([]iter.Seq[tagged], k)
+		for i := range s {
+			seqs[i] = slices.Values(s[i])
+		}
+		check(t, "Seqs", s, slices.Collect(Seqs(lessTagged, seqs)))
+	}
+}
+
+func TestSeqsStopsEarly(t *testing.T) {
+	stopped := 0
+	counter := func(start int) iter.Seq[int] { // unbounded
+		return func(yield func(int) bool) {
+			defer func() { stopped++ }()
+			for i := start; yield(i); i += 2 {
+			}
+		}
+	}
+	var got []int
+	for v := range Seqs(func(a, b int) bool { return a < b }, []iter.Seq[int]{counter(0), counter(1)}) {
+		if v == 5 {
+			break
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("Seqs = %v; want [0 1 2 3 4]", got)
+	}
+	if stopped != 2 {
+		t.Errorf("%d of 2 seqs stopped after break", stopped)
+	}
+}
+
+func TestChannels(t *testing.T) {
+	r := rand.New(rand.NewSource(43))
+	for _, k := range []int{0, 1, 2, 7, 64} {
+		s := shards(r, k)
+		chans := make([]<-chan tagged, k)
+		for i := range s {
+			ch := make(chan tagged)
+			go func(shard []tagged) {
+				for _, v := range shard {
+					ch <- v
+				}
+				close(ch)
+			}(s[i])
+			chans[i] = ch
+		}
+		check(t, "Channels", s, slices.Collect(Channels(lessTagged, chans)))
+	}
+}
+
+func BenchmarkSlices(b *testing.B) {
+	r := rand.New(rand.NewSource(43))
+	s := make([][]int, 64)
+	for i := range s {
+		s[i] = make([]int, 1<<12)
+		for j := range s[i] {
+			s[i][j] = r.Int()
+		}
+		sort.Ints(s[i])
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Slices(func(a, b int) bool { return a < b }, s)
+	}
+}
@@ -0,0 +1,82 @@
+package sort
+
+// A sorting network is a fixed sequence of comparators: each compares
+// the elements at two indices and swaps them if they are out of order.
+// The sequence does not depend on the data, which makes it a good fit
+// for tiny inputs where a loop's bookkeeping costs as much as the sort.
+
+// The smallest known networks for 2 to 8 elements, all proven optimal
+// in comparator count.
+var smallNetworks = [][][2]int{
+	{},
+	{},
+	{{0, 1}},
+	{{0, 2}, {0, 1}, {1, 2}},
+	{{0, 2}, {1, 3}, {0, 1}, {2, 3}, {1, 2}},
+	{{0, 3}, {1, 4}, {0, 2}, {1, 3}, {0, 1}, {2, 4}, {1, 2}, {3, 4}, {2, 3}},
+	{{0, 5}, {1, 3}, {2, 4}, {1, 2}, {3, 4}, {0, 3}, {2, 5}, {0, 1}, {2, 3}, {4, 5}, {1, 2}, {3, 4}},
+	{{0, 6}, {2, 3}, {4, 5}, {0, 2}, {1, 4}, {3, 6}, {0, 1}, {2, 5}, {3, 4}, {1, 2}, {4, 6}, {2, 3}, {4, 5}, {1, 2}, {3, 4}, {5, 6}},
+	{{0, 2}, {1, 3}, {4, 6}, {5, 7}, {0, 4}, {1, 5}, {2, 6}, {3, 7}, {0, 1}, {2, 3}, {4, 5}, {6, 7}, {2, 4}, {3, 5}, {1, 4}, {3, 6}, {1, 2}, {3, 4}, {5, 6}},
+}
+
+// Networks for up to this many elements are built once and cached.
+const networkCutoff = 16
+
+// Returns the comparators of Batcher's odd-even merge sort for n
+// elements, which uses O(n lg^2 n) comparators.
+func batcher(n int) [][2]int {
+	var network [][2]int
+	for p := 1; p < n; p <<= 1 {
+		for k := p; k >= 1; k >>= 1 {
+			for j := k % p; j+k < n; j += 2 * k {
+				for i := 0; i < k && i+j+k < n; i++ {
+					if (i+j)/(2*p) == (i+j+k)/(2*p) {
+						network = append(network, [2]int{i + j, i + j + k})
+					}
+				}
+			}
+		}
+	}
+	return network
+}
+
+// Networks for up to networkCutoff elements, built once.
+var networks = func() [][][2]int {
+	ns := append([][][2]int(nil), smallNetworks...)
+	for n := len(ns); n <= networkCutoff; n++ {
+		ns = append(ns, batcher(n))
+	}
+	return ns
+}()
+
+// Returns a sorting network for n elements as a list of comparators
+// {i, j} with i < j, to be applied in order. For n up to 8 the network
+// is the smallest known; beyond that it is Batcher's odd-even merge sort.
+// The returned slice must not be modified.
+func SortingNetwork(n int) [][2]int {
+	if n < len(networks) {
+		return networks[max(n, 0)]
+	}
+	return batcher(n)
+}
+
+// Applies network to the elements starting at from.
+func applyNetwork(s Sortable, from int, network [][2]int) {
+	for _, c := range network {
+		if s.Less(from+c[1], from+c[0]) {
+			s.Swap(from+c[0], from+c[1])
+		}
+	}
+}
+
+// Sorts a Sortable collection with a sorting network. It makes the same
+// comparisons whatever the input, which suits small collections; for
+// more than a few dozen elements use IntroSort. It is not stable.
+func NetworkSort(s Sortable) {
+	NetworkSortRange(s, 0, s.Len())
+}
+
+// Sorts the elements in [from, to) of a Sortable collection with a sorting network.
+func NetworkSortRange(s Sortable, from, to int) {
+	applyNetwork(s, from, SortingNetwork(to-from))
+}
@@ -0,0 +1,105 @@
+package sort
+
+import (
+	"testing"
+)
+
+// By the 0-1 principle, a network sorts every input if it sorts every
+// input of only 0s and 1s, so checking all 2^n of those is a proof.
+func TestSortingNetworks(t *testing.T) {
+	for n := 0; n <= 16; n++ {
+		network := SortingNetwork(n)
+		for _, c := range network {
+			if c[0] < 0 || c[0] >= c[1] || c[1] >= n {
+				t.Fatalf("SortingNetwork(%d) has bad comparator %v", n, c)
+			}
+		}
+		s := make(Ints, n)
+		for bits := 0; bits < 1<<n; bits++ {
+			for i := range s {
+				s[i] = bits >> i & 1
+			}
+			applyNetwork(s, 0, network)
+			if !IsSorted(s) {
+				t.Fatalf("SortingNetwork(%d) does not sort %0*b", n, n, bits)
+			}
+		}
+	}
+}
+
+func TestSortingNetworkSizes(t *testing.T) {
+	// the smallest known comparator counts
+	optimal := []int{0, 0, 1, 3, 5, 9, 12, 16, 19}
+	for n, want := range optimal {
+		if got := len(SortingNetwork(n)); got != want {
+			t.Errorf("SortingNetwork(%d) has %d comparators; want %d", n, got, want)
+		}
+	}
+	if got := len(SortingNetwork(16)); got != 63 {
+		t.Errorf("SortingNetwork(16) has %d comparators; want 63", got)
+	}
+}
+
+func TestNetworkSortRange(t *testing.T) {
+	testSortRange(t, "NetworkSortRange", NetworkSortRange)
+}
+
+func benchmarkSmall(b *testing.B, n int, sort func(Sortable)) {
+	ins := adversarialInputs(n)["random"]
+	s := make(Ints, n)
+	for i := 0; i < b.N; i++ {
+		copy(s, ins)
+		sort(s)
+	}
+}
+
+func BenchmarkInsertionSort4(b *testing.B)  { benchmarkSmall(b, 4, InsertionSort) }
+func BenchmarkNetworkSort4(b *testing.B)    { benchmarkSmall(b, 4, NetworkSort) }
+func BenchmarkInsertionSort8(b *testing.B)  { benchmarkSmall(b, 8, InsertionSort) }
+func BenchmarkNetworkSort8(b *testing.B)    { benchmarkSmall(b, 8, NetworkSort) }
+func BenchmarkInsertionSort16(b *testing.B) { benchmarkSmall(b, 16, InsertionSort) }
+func BenchmarkNetworkSort16(b *testing.B)   { benchmarkSmall(b, 16, NetworkSort) }
+
+// Small range paths for QuickSort and IntroSort: insertion sort or a
+// sorting network, below a few cutoffs. smallRanges is the one in use.
+var smallPaths = []struct {
+	name  string
+	small smallSorter
+}{
+	{"Insertion8", smallSorter{8, InsertionSortRange}},
+	{"Insertion12", smallRanges},
+	{"Insertion16", smallSorter{16, InsertionSortRange}},
+	{"Insertion20", smallSorter{20, InsertionSortRange}},
+	{"Network4", smallSorter{4, NetworkSortRange}},
+	{"Network8", smallSorter{8, NetworkSortRange}},
+	{"Network16", smallSorter{16, NetworkSortRange}},
+}
+
+func TestSmallPaths(t *testing.T) {
+	for _, p := range smallPaths {
+		testSortRange(t, "quickSort/"+p.name, func(s Sortable, from, to int) {
+			quickSort(s, from, to, p.small)
+		})
+		testSortRange(t, "introSort/"+p.name, func(s Sortable, from, to int) {
+			introSort(s, from, to, maxDepth(to-from), p.small)
+		})
+	}
+}
+
+func BenchmarkQuickSortSmall(b *testing.B) {
+	for _, p := range smallPaths {
+		b.Run(p.name, func(b *testing.B) {
+			benchmarkSort(b, "random", func(s Sortable) { quickSort(s, 0, s.Len(), p.small) })
+		})
+	}
+}
+
+func BenchmarkIntroSortSmall(b *testing.B) {
+	for _, p := range smallPaths {
+		b.Run(p.name, func(b *testing.B) {
+			benchmarkSort(b, "random", func(s Sortable) {
+				introSort(s, 0, s.Len(), maxDepth(s.Len()), p.small)
+			})
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package sort
+
+import (
+	"runtime"
+	"sync"
+)
+
+// DefaultParallelCutoff is the length at or below which ParallelSortFunc
+// sorts a range on the goroutine that partitioned it, when it is given a
+// cutoff of 0.
+const DefaultParallelCutoff = 1 << 12
+
+type parallelSort[T any] struct {
+	f      funcSortable[T]
+	sem    chan struct{} // a token for every goroutine beyond the caller's
+	cutoff int
+	wg     sync.WaitGroup
+}
+
+// Sorts [from, to) like introSort, handing the left side of every
+// partition to a new goroutine if a token is free.
+func (p *parallelSort[T]) sort(from, to, depth int) {
+	for to-from > p.cutoff {
+		if depth == 0 {
+			HeapSortRange(p.f, from, to)
+			return
+		}
+		depth--
+		m := partition(p.f, from, to, choosePivot(p.f, from, to))
+		select {
+		case p.sem <- struct{}{}:
+			p.wg.Add(1)
+			go func(from, to, depth int) {
+				p.sort(from, to, depth)
+				<-p.sem
+				p.wg.Done()
+			}(from, m, depth)
+		default:
+			p.sort(from, m, depth)
+		}
+		from = m + 1
+	}
+	introSort(p.f, from, to, depth, smallRanges)
+}
+
+// Sorts s in place by cmp with a parallel introsort that runs on at most
+// workers goroutines at once. Every partition hands one side to a free
+// worker and keeps the other; ranges of at most cutoff elements are
+// sorted sequentially. A workers of 0 uses GOMAXPROCS and a cutoff of 0
+// uses DefaultParallelCutoff.
+//
+// The sort is not stable, but which pivots are picked and how ranges
+// are split depends only on s, cmp and cutoff, never on workers or
+// scheduling, so the output is the same for any number of workers.
+func ParallelSortFunc[T any](s []T, cmp func(a, b T) int, workers, cutoff int) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if cutoff <= 0 {
+		cutoff = DefaultParallelCutoff
+	}
+	p := &parallelSort[T]{
+		f:      funcSortable[T]{s, cmp},
+		sem:    make(chan struct{}, workers-1),
+		cutoff: cutoff,
+	}
+	p.sort(0, len(s), maxDepth(len(s)))
+	p.wg.Wait()
+}
@@ -0,0 +1,74 @@
+package sort
+
+import (
+	"cmp"
+	"math/rand"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func TestParallelSortFunc(t *testing.T) {
+	for _, n := range []int{0, 1, 100, 10000} {
+		for input, in := range adversarialInputs(n) {
+			want := append([]int(nil), in...)
+			slices.Sort(want)
+			for _, workers := range []int{0, 1, 3, 8} {
+				s := append([]int(nil), in...)
+				ParallelSortFunc(s, cmp.Compare[int], workers, 64)
+				if !slices.Equal(s, want) {
+					t.Fatalf("ParallelSortFunc(%s, n=%d, workers=%d) not sorted", input, n, workers)
+				}
+			}
+		}
+	}
+}
+
+// Equal keys are told apart by their tags, so any difference in how
+// the sort ran shows up in the output.
+func TestParallelSortFuncDeterministic(t *testing.T) {
+	r := rand.New(rand.NewSource(46))
+	in := make([]tagged, 50000)
+	for i := range in {
+		in[i] = tagged{key: r.Intn(100), tag: i}
+	}
+	byKey := func(a, b tagged) int { return cmp.Compare(a.key, b.key) }
+	want := append([]tagged(nil), in...)
+	ParallelSortFunc(want, byKey, 1, 256)
+	for _, workers := range []int{2, 4, 16} {
+		for trial := 0; trial < 3; trial++ {
+			s := append([]tagged(nil), in...)
+			ParallelSortFunc(s, byKey, workers, 256)
+			if !slices.Equal(s, want) {
+				t.Fatalf("ParallelSortFunc with %d workers differs from 1 worker", workers)
+			}
+		}
+	}
+}
+
+func benchmarkParallelSort(b *testing.B, workers int) {
+	in := rand.New(rand.NewSource(46)).Perm(1 << 20)
+	s := make([]int, len(in))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(s, in)
+		ParallelSortFunc(s, cmp.Compare[int], workers, 0)
+	}
+}
+
+func BenchmarkIntroSortFuncLarge(b *testing.B) {
+	in := rand.New(rand.NewSource(46)).Perm(1 << 20)
+	s := make([]int, len(in))
+	for i := 0; i < b.N; i++ {
+		copy(s, in)
+		IntroSortFunc(s, cmp.Compare[int])
+	}
+}
+
+func BenchmarkParallelSortFunc1(b *testing.B) { benchmarkParallelSort(b, 1) }
+func BenchmarkParallelSortFunc2(b *testing.B) { benchmarkParallelSort(b, 2) }
+func BenchmarkParallelSortFunc4(b *testing.B) { benchmarkParallelSort(b, 4) }
+func BenchmarkParallelSortFunc8(b *testing.B) { benchmarkParallelSort(b, 8) }
+func BenchmarkParallelSortFuncMax(b *testing.B) {
+	benchmarkParallelSort(b, runtime.GOMAXPROCS(0))
+}
@@ -1,31 +1,87 @@
 package sort
 
-// currently chooses pivot to be s[to].
-func partition(s Sortable, from, to int) int {
-	i := from
-	for j := from; j < to-1; j++ {
-		if s.Less(j, to-1) {
-			s.Swap(i, j)
+// Returns the index of the median of the elements at a, b and c.
+func medianOfThree(s Sortable, a, b, c int) int {
+	if s.Less(b, a) {
+		a, b = b, a
+	}
+	// s[a] <= s[b]
+	if s.Less(c, b) {
+		if s.Less(c, a) {
+			return a
+		}
+		return c
+	}
+	return b
+}
+
+// Partitions the elements in [from, to) around the element at pivot and
+// returns the pivot's final index. Everything before it is not greater
+// than the pivot and everything after it is not less.
+//
+// Both scans stop on elements equal to the pivot, which splits runs of
+// equal elements evenly instead of piling them all on one side.
+func partition(s Sortable, from, to, pivot int) int {
+	s.Swap(from, pivot)
+	i, j := from+1, to-1
+	for {
+		for i <= j && s.Less(i, from) {
 			i++
 		}
+		for i <= j && s.Less(from, j) {
+			j--
+		}
+		if i >= j {
+			break
+		}
+		s.Swap(i, j)
+		i++
+		j--
 	}
-	s.Swap(to-1, i)
-	return i
+	s.Swap(from, j)
+	return j
 }
 
-// TODO: median of three pivot,
-//       tail call so only one recursive call
-//       insertion sort for small values
-//       wtf are the go authors doing with heapsort
-//       dual pivot
-func quickSort(s Sortable, from, to int) {
-	if to-from > 1 {
-		pivot := partition(s, from, to)
-		quickSort(s, from, pivot)
-		quickSort(s, pivot+1, to)
+// How the divide and conquer sorts finish small ranges: ranges of at
+// most cutoff elements are sorted with sort.
+type smallSorter struct {
+	cutoff int
+	sort   func(s Sortable, from, to int)
+}
+
+// QuickSort and IntroSort finish ranges of at most 12 elements with
+// insertion sort. See BenchmarkQuickSortSmall: through Sortable, a
+// sorting network makes at least as many calls to Less and Swap as
+// insertion sort on random data at every size from 2 to 16 and is no
+// faster at any of them, and cutoffs from 10 to 20 are within noise
+// of each other.
+var smallRanges = smallSorter{12, InsertionSortRange}
+
+// Recurses on the smaller side of every partition and loops on the
+// larger, so the stack never grows past O(lg n).
+func quickSort(s Sortable, from, to int, small smallSorter) {
+	for to-from > small.cutoff {
+		p := partition(s, from, to, medianOfThree(s, from, from+(to-from)/2, to-1))
+		if p-from < to-p-1 {
+			quickSort(s, from, p, small)
+			from = p + 1
+		} else {
+			quickSort(s, p+1, to, small)
+			to = p
+		}
 	}
+	small.sort(s, from, to)
 }
 
+// Runs quicksort on a Sortable collection, pivoting on the median of the
+// first, middle and last elements. Runs in O(n * lg n) expected time;
+// sorted and reversed input no longer hit the O(n^2) worst case, but
+// crafted input still can. IntroSort never does.
 func QuickSort(s Sortable) {
-	quickSort(s, 0, s.Len())
+	quickSort(s, 0, s.Len(), smallRanges)
+}
+
+// Runs quicksort on the elements in [from, to) of a Sortable collection.
+func QuickSortRange(s Sortable, from, to int) {
+	quickSort(s, from, to, smallRanges)
 }
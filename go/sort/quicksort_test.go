@@ -28,3 +28,15 @@ func TestQuickSort(t *testing.T) {
 		}
 	}
 }
+
+func TestQuickSortRange(t *testing.T) {
+	testSortRange(t, "QuickSortRange", QuickSortRange)
+}
+
+func TestMedianOfThree(t *testing.T) {
+	for _, s := range [][]int{{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}, {2, 2, 1}, {2, 2, 2}} {
+		if got := Ints(s)[medianOfThree(Ints(s), 0, 1, 2)]; got != 2 {
+			t.Errorf("medianOfThree(%v) = %v; want 2", s, got)
+		}
+	}
+}
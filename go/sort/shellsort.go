@@ -0,0 +1,39 @@
+package sort
+
+// Ciura's gap sequence, extended past 701 by multiplying by 2.25.
+var ciuraGaps = []int{1, 4, 10, 23, 57, 132, 301, 701}
+
+// Returns the gaps to use for n elements, largest first.
+func shellGaps(n int) []int {
+	gaps := append([]int(nil), ciuraGaps...)
+	for g := gaps[len(gaps)-1]; g < n/2; {
+		g = g * 9 / 4
+		gaps = append(gaps, g)
+	}
+	var used []int
+	for i := len(gaps) - 1; i >= 0; i-- {
+		if gaps[i] < n {
+			used = append(used, gaps[i])
+		}
+	}
+	return used
+}
+
+// Runs shellsort on a Sortable collection. Shellsort is insertion sort
+// over elements a gap apart with shrinking gaps, ending with a gap of 1.
+// It needs no extra space or recursion and is quick for moderate n,
+// but is not stable.
+func ShellSort(s Sortable) {
+	ShellSortRange(s, 0, s.Len())
+}
+
+// Runs shellsort on the elements in [from, to) of a Sortable collection.
+func ShellSortRange(s Sortable, from, to int) {
+	for _, gap := range shellGaps(to - from) {
+		for j := from + gap; j < to; j++ {
+			for i := j; i-gap >= from && s.Less(i, i-gap); i -= gap {
+				s.Swap(i, i-gap)
+			}
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package sort
+
+import "testing"
+
+func TestShellSort(t *testing.T) {
+	s := Ints(append([]int(nil), ints...))
+	ShellSort(s)
+	for i := 1; i < len(s); i++ {
+		if s[i] < s[i-1] {
+			t.Fatalf("ShellSort result not sorted: %v", s)
+		}
+	}
+}
+
+func TestShellSortRange(t *testing.T) {
+	testSortRange(t, "ShellSortRange", ShellSortRange)
+}
+
+func TestShellGaps(t *testing.T) {
+	if gaps := shellGaps(1); len(gaps) != 0 {
+		t.Errorf("shellGaps(1) = %v; want none", gaps)
+	}
+	gaps := shellGaps(100000)
+	if gaps[len(gaps)-1] != 1 {
+		t.Errorf("shellGaps does not end in 1: %v", gaps)
+	}
+	for i := 1; i < len(gaps); i++ {
+		if gaps[i] >= gaps[i-1] {
+			t.Errorf("shellGaps not decreasing: %v", gaps)
+		}
+	}
+}
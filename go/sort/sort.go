@@ -23,11 +23,16 @@ type Sortable interface {
 	Swap(i, j int)
 }
 
-// Insertion sort on Sortable type
-// Does not have start and end indices yet, like the Go authors of sort
+// Insertion sort on Sortable type.
+// Runs in O(n^2) time, but is fast for small or nearly sorted collections.
 func InsertionSort(stuff Sortable) {
-	for j := 1; j < stuff.Len(); j++ { // from the second spot to the last
-		for i := j; i > 0 && stuff.Less(i, i-1); i-- { // while left is larger
+	InsertionSortRange(stuff, 0, stuff.Len())
+}
+
+// Runs insertion sort on the elements in [from, to) of a Sortable collection.
+func InsertionSortRange(stuff Sortable, from, to int) {
+	for j := from + 1; j < to; j++ { // from the second spot to the last
+		for i := j; i > from && stuff.Less(i, i-1); i-- { // while left is larger
 			stuff.Swap(i, i-1) // slide right one position
 		}
 	}
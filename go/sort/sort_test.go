@@ -1,6 +1,10 @@
 package sort
 
-import "testing"
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
 
 type Ints []int
 
@@ -31,3 +35,71 @@ func TestInsertionSort(t *testing.T) {
 		}
 	}
 }
+
+// Returns inputs of length n that are known to be hard for some sorts.
+func adversarialInputs(n int) map[string][]int {
+	inputs := map[string][]int{
+		"random":    rand.New(rand.NewSource(int64(n))).Perm(n),
+		"sorted":    make([]int, n),
+		"reversed":  make([]int, n),
+		"equal":     make([]int, n),
+		"organPipe": make([]int, n),
+		"sawtooth":  make([]int, n),
+		"fewUnique": make([]int, n),
+		"m3killer":  make([]int, n),
+	}
+	r := rand.New(rand.NewSource(int64(n)))
+	for i := 0; i < n; i++ {
+		inputs["sorted"][i] = i
+		inputs["reversed"][i] = n - i
+		inputs["equal"][i] = 7
+		inputs["organPipe"][i] = min(i, n-i)
+		inputs["sawtooth"][i] = i % 17
+		inputs["fewUnique"][i] = r.Intn(4)
+	}
+	// Musser's median-of-3 killer sequence
+	k := n / 2
+	for i := 1; i <= k; i++ {
+		if i%2 == 1 {
+			inputs["m3killer"][i-1] = i
+			inputs["m3killer"][i] = k + i
+		}
+		inputs["m3killer"][k+i-1] = 2 * i
+	}
+	return inputs
+}
+
+// Counts calls to Less.
+type countingInts struct {
+	Ints
+	less int
+}
+
+func (c *countingInts) Less(i, j int) bool {
+	c.less++
+	return c.Ints.Less(i, j)
+}
+
+// Sorts the middle of every adversarial input with sortRange and checks that
+// the range is sorted, holds the same elements, and nothing else moved.
+func testSortRange(t *testing.T, name string, sortRange func(Sortable, int, int)) {
+	for _, n := range []int{0, 1, 2, 3, 13, 41, 100, 1000} {
+		for input, in := range adversarialInputs(n) {
+			s := append(Ints(nil), in...)
+			from, to := n/5, n-n/7
+			sortRange(s, from, to)
+			want := append([]int(nil), in...)
+			sort.Ints(want[from:to])
+			for i := range want {
+				if s[i] != want[i] {
+					t.Errorf("%s(%s, n=%d): [%d] = %v; want %v", name, input, n, i, s[i], want[i])
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestInsertionSortRange(t *testing.T) {
+	testSortRange(t, "InsertionSortRange", InsertionSortRange)
+}
@@ -0,0 +1,155 @@
+package sort
+
+// Runs of this many elements are insertion sorted before merging.
+const stableBlockSize = 20
+
+// Runs a stable sort on a Sortable collection: equal elements keep their
+// original order. It sorts in place with no extra memory by insertion
+// sorting small blocks and merging them with SymMerge, which merges by
+// rotating rather than copying. Makes O(n * lg n) calls to Less and
+// O(n * lg n * lg n) calls to Swap.
+func StableSort(s Sortable) {
+	StableSortRange(s, 0, s.Len())
+}
+
+// Runs a stable sort on the elements in [from, to) of a Sortable collection.
+func StableSortRange(s Sortable, from, to int) {
+	block := stableBlockSize
+	a, b := from, from+block
+	for b <= to {
+		InsertionSortRange(s, a, b)
+		a, b = b, b+block
+	}
+	InsertionSortRange(s, a, to)
+
+	for ; block < to-from; block *= 2 {
+		a, b = from, from+2*block
+		for b <= to {
+			symMerge(s, a, a+block, b)
+			a, b = b, b+2*block
+		}
+		if m := a + block; m < to {
+			symMerge(s, a, m, to)
+		}
+	}
+}
+
+// Merges the sorted ranges [a, m) and [m, b) in place, stably.
+//
+// This is SymMerge from Kim and Kutzner, "Stable Minimum Storage Merging
+// by Symmetric Comparisons". It finds how much of the end of the left run
+// belongs after how much of the start of the right run, rotates those two
+// pieces past each other and recurses on both halves.
+func symMerge(s Sortable, a, m, b int) {
+	// a single element can be binary inserted, which is much cheaper
+	if m-a == 1 {
+		i, j := m, b
+		for i < j { // first index in [m, b) not less than s[a]
+			h := int(uint(i+j) >> 1)
+			if s.Less(h, a) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		for k := a; k < i-1; k++ {
+			s.Swap(k, k+1)
+		}
+		return
+	}
+	if b-m == 1 {
+		i, j := a, m
+		for i < j { // first index in [a, m) greater than s[m]
+			h := int(uint(i+j) >> 1)
+			if !s.Less(m, h) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		for k := m; k > i; k-- {
+			s.Swap(k, k-1)
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !s.Less(p-c, c) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+	end := n - start
+	if start < m && m < end {
+		rotate(s, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge(s, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge(s, mid, end, b)
+	}
+}
+
+// Swaps the n elements starting at a with the n elements starting at b.
+func swapRange(s Sortable, a, b, n int) {
+	for i := 0; i < n; i++ {
+		s.Swap(a+i, b+i)
+	}
+}
+
+// Rotates [a, b) so that [m, b) comes before [a, m), using block swaps.
+func rotate(s Sortable, a, m, b int) {
+	i := m - a
+	j := b - m
+	for i != j {
+		if i > j {
+			swapRange(s, m-i, m, j)
+			i -= j
+		} else {
+			swapRange(s, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	swapRange(s, m-i, m, i)
+}
+
+// Returns whether a Sortable collection is sorted.
+func IsSorted(s Sortable) bool {
+	for i := s.Len() - 1; i > 0; i-- {
+		if s.Less(i, i-1) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns whether a Sortable collection is sorted and every run of
+// equal elements is in increasing order of tag, where tag(i) is the
+// original position of the element now at i. Tagging elements with
+// their position before sorting and checking IsStable afterwards
+// verifies that a sort is stable.
+func IsStable(s Sortable, tag func(i int) int) bool {
+	for i := 1; i < s.Len(); i++ {
+		if s.Less(i, i-1) {
+			return false
+		}
+		if !s.Less(i-1, i) && tag(i) < tag(i-1) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package sort
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// A key and the position it started at. Only keys are compared.
+type tagged struct {
+	key, tag int
+}
+
+type taggedSlice []tagged
+
+func (p taggedSlice) Len() int           { return len(p) }
+func (p taggedSlice) Less(i, j int) bool { return p[i].key < p[j].key }
+func (p taggedSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+func newTagged(keys []int) taggedSlice {
+	s := make(taggedSlice, len(keys))
+	for i, k := range keys {
+		s[i] = tagged{key: k, tag: i}
+	}
+	return s
+}
+
+func TestStableSort(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 19, 20, 21, 100, 1000, 4099} {
+		for input, in := range adversarialInputs(n) {
+			keys := append([]int(nil), in...)
+			for i := range keys {
+				keys[i] %= 10 // lots of duplicates
+			}
+			s := newTagged(keys)
+			StableSort(s)
+			if !IsStable(s, func(i int) int { return s[i].tag }) {
+				t.Errorf("StableSort(%s, n=%d) not stable: %v", input, n, s)
+			}
+		}
+	}
+}
+
+func TestStableSortRange(t *testing.T) {
+	testSortRange(t, "StableSortRange", StableSortRange)
+	r := rand.New(rand.NewSource(38))
+	keys := make([]int, 500)
+	for i := range keys {
+		keys[i] = r.Intn(5)
+	}
+	s := newTagged(keys)
+	StableSortRange(s, 100, 400)
+	if !IsStable(s[100:400], func(i int) int { return s[100+i].tag }) {
+		t.Errorf("StableSortRange not stable")
+	}
+	for i := 0; i < 100; i++ {
+		if s[i].tag != i || s[499-i].tag != 499-i {
+			t.Fatalf("StableSortRange moved elements outside of the range")
+		}
+	}
+}
+
+func TestIsSortedIsStable(t *testing.T) {
+	tests := []struct {
+		keys           []int
+		tags           []int
+		sorted, stable bool
+	}{
+		{[]int{}, []int{}, true, true},
+		{[]int{1, 2, 2, 3}, []int{0, 1, 2, 3}, true, true},
+		{[]int{1, 2, 2, 3}, []int{0, 2, 1, 3}, true, false},
+		{[]int{1, 3, 2}, []int{0, 1, 2}, false, false},
+	}
+	for _, test := range tests {
+		s := make(taggedSlice, len(test.keys))
+		for i := range s {
+			s[i] = tagged{test.keys[i], test.tags[i]}
+		}
+		if got := IsSorted(s); got != test.sorted {
+			t.Errorf("IsSorted(%v) = %v; want %v", s, got, test.sorted)
+		}
+		if got := IsStable(s, func(i int) int { return s[i].tag }); got != test.stable {
+			t.Errorf("IsStable(%v) = %v; want %v", s, got, test.stable)
+		}
+	}
+}
+
+// IntroSort is not stable; make sure IsStable can tell.
+func TestIsStableCatchesUnstable(t *testing.T) {
+	keys := make([]int, 1000)
+	r := rand.New(rand.NewSource(38))
+	for i := range keys {
+		keys[i] = r.Intn(3)
+	}
+	s := newTagged(keys)
+	IntroSort(s)
+	if !IsSorted(s) {
+		t.Fatalf("IntroSort did not sort")
+	}
+	if IsStable(s, func(i int) int { return s[i].tag }) {
+		t.Errorf("IsStable reported IntroSort of many duplicates as stable")
+	}
+}
+
+func BenchmarkStableSortRandom(b *testing.B) { benchmarkSort(b, "random", StableSort) }
@@ -20,18 +20,56 @@ type node struct {
 
 // A type that implements the comparable interface can be used in binary trees.
 type Comparable interface {
-	// Returns -1 if other is less, 0 if they are equal and 1 if other is greater.
+	// Returns a negative number if the receiver is less than other,
+	// 0 if they are equal and a positive number if the receiver is greater.
 	CompareTo(other Comparable) int
 }
 
+// OrderedSet is the API shared by BinaryTree and the other Comparable
+// ordered sets under tree, so that callers can swap implementations.
+// Equal values may be inserted more than once.
+type OrderedSet interface {
+	Insert(value Comparable)
+	Delete(value Comparable) (Comparable, bool)
+	Contains(target Comparable) bool
+	Minimum() (Comparable, bool)
+	Maximum() (Comparable, bool)
+	Successor(target Comparable) (Comparable, bool)
+	Walk() []Comparable
+	Len() int
+}
+
+// Len returns the number of values in the binary tree.
+func (b *BinaryTree) Len() int {
+	return b.size
+}
+
+// Clear removes all values from the binary tree.
+func (b *BinaryTree) Clear() {
+	b.root = nil
+	b.size = 0
+}
+
+func height(node *node) int {
+	if node == nil {
+		return 0
+	}
+	return max(height(node.left), height(node.right)) + 1
+}
+
+// Height returns the number of nodes on the longest path from the root
+// to a leaf. An empty tree has height 0.
+func (b *BinaryTree) Height() int {
+	return height(b.root)
+}
+
 func walkInOrder(node *node, walked []Comparable) []Comparable {
 	if node != nil {
 		walked = walkInOrder(node.left, walked)
 		walked = append(walked, node.value)
 		walked = walkInOrder(node.right, walked)
-		return walked
 	}
-	return nil
+	return walked
 }
 
 // Returns an in order Comparable slice of the binary tree.
@@ -45,9 +83,8 @@ func walkPreOrder(node *node, walked []Comparable) []Comparable {
 		walked = append(walked, node.value)
 		walked = walkPreOrder(node.left, walked)
 		walked = walkPreOrder(node.right, walked)
-		return walked
 	}
-	return nil
+	return walked
 }
 
 // Returns a pre order Comparable slice of the binary tree.
@@ -61,9 +98,8 @@ func walkPostOrder(node *node, walked []Comparable) []Comparable {
 		walked = walkPostOrder(node.left, walked)
 		walked = walkPostOrder(node.right, walked)
 		walked = append(walked, node.value)
-		return walked
 	}
-	return nil
+	return walked
 }
 
 // Returns a post order Comparable slice of the binary tree.
@@ -77,13 +113,14 @@ func (b *BinaryTree) WalkPostOrder() []Comparable {
 func (b *BinaryTree) search(target Comparable) *node {
 	current := b.root
 	for current != nil {
-		switch current.value.CompareTo(target) {
-		case -1:
+		c := target.CompareTo(current.value)
+		switch {
+		case c < 0:
 			current = current.left
-		case 0:
-			return current
-		case 1:
+		case c > 0:
 			current = current.right
+		default:
+			return current
 		}
 	}
 	return nil
@@ -104,46 +141,62 @@ func minimum(startNode *node) *node {
 	return current
 }
 
-// Returns a pointer to a copy of the minimum value
-// in the binary tree. If the tree is empty, this will
-// return nil.
-func (b *BinaryTree) Minimum() *Comparable {
-	return &minimum(b.root).value
-}
-
-// Returns a pointer to a copy of the maximum value
-// in the binary tree. If the tree is empty, this will
-// return nil.
-func (b *BinaryTree) Maximum() *Comparable {
-	current := b.root
+// returns a pointer to the node that contains the maximum
+// starting from the start node
+func maximum(startNode *node) *node {
+	current := startNode
 	for current.right != nil {
 		current = current.right
 	}
-	// copy
-	rval := current.value
-	return &rval
+	return current
 }
 
-// Returns a pointer to the successor value of a target Comparable in a binary tree.
-// The pointer will be nil if the tree does not contain the target
-// of if there is no successor (i.e., you want the successor to the maximum value)
-func (b *BinaryTree) Successor(target Comparable) *Comparable {
-	current := b.search(target)
-	if current == nil {
-		return nil
+// Returns the minimum value in the binary tree.
+// If the tree is empty, ok is false.
+func (b *BinaryTree) Minimum() (min Comparable, ok bool) {
+	if b.root == nil {
+		return nil, false
 	}
-	if current.right != nil {
-		return &minimum(current.right).value
+	return minimum(b.root).value, true
+}
+
+// Returns the maximum value in the binary tree.
+// If the tree is empty, ok is false.
+func (b *BinaryTree) Maximum() (max Comparable, ok bool) {
+	if b.root == nil {
+		return nil, false
 	}
-	if current == b.root { // if root has no right side, at max, no successor
-		return nil
+	return maximum(b.root).value, true
+}
+
+// returns the node following current in an in order walk,
+// or nil if current holds the maximum
+func next(current *node) *node {
+	if current.right != nil {
+		return minimum(current.right)
 	}
 	parent := current.parent
 	for parent != nil && current == parent.right {
 		current = parent
 		parent = current.parent
 	}
-	return &current.value
+	return parent
+}
+
+// Returns the smallest value in the binary tree that is greater than target.
+// ok is false if the tree does not contain the target
+// or if there is no successor (i.e., you want the successor to the maximum value)
+func (b *BinaryTree) Successor(target Comparable) (succ Comparable, ok bool) {
+	current := b.search(target)
+	if current == nil {
+		return nil, false
+	}
+	for current = next(current); current != nil; current = next(current) {
+		if current.value.CompareTo(target) > 0 {
+			return current.value, true
+		}
+	}
+	return nil, false
 }
 
 // Inserts a comparable value into a binary tree.
@@ -156,7 +209,7 @@ func (b *BinaryTree) Insert(value Comparable) {
 	x := b.root
 	for x != nil {
 		y = x
-		if value.CompareTo(x.value) == -1 { // less
+		if value.CompareTo(x.value) < 0 { // less
 			x = x.left
 		} else {
 			x = x.right
@@ -165,7 +218,7 @@ func (b *BinaryTree) Insert(value Comparable) {
 	newNode.parent = y
 	if newNode.parent == nil {
 		b.root = newNode
-	} else if newNode.value.CompareTo(y.value) == -1 {
+	} else if newNode.value.CompareTo(y.value) < 0 {
 		y.left = newNode
 	} else {
 		y.right = newNode
@@ -194,15 +247,14 @@ func (b *BinaryTree) transplant(old, replacement *node) {
 	old.right = nil
 }
 
-// Deletes and returns a pointer to a Comparable value from
-// the binary tree. If the value was not in the binary tree,
-// this function returns nil. Running time is O(h) for a tree of
-// height h.
-func (b *BinaryTree) Delete(value Comparable) *Comparable {
+// Deletes and returns a Comparable value from the binary tree.
+// If the value was not in the binary tree, ok is false.
+// Running time is O(h) for a tree of height h.
+func (b *BinaryTree) Delete(value Comparable) (deleted Comparable, ok bool) {
 	// first find the node because of my decision to not allow access to nodes.
 	node := b.search(value)
 	if node == nil {
-		return nil
+		return nil, false
 	}
 	if node.left == nil {
 		b.transplant(node, node.right)
@@ -213,11 +265,12 @@ func (b *BinaryTree) Delete(value Comparable) *Comparable {
 		if replacement.parent != node {
 			b.transplant(replacement, replacement.right) // sever ties
 			replacement.right = node.right
+			replacement.right.parent = replacement
 		}
-		replacement.right.parent = replacement
 		replacement.left = node.left
 		replacement.left.parent = replacement
 		b.transplant(node, replacement)
 	}
-	return &node.value
+	b.size--
+	return node.value, true
 }
@@ -1,11 +1,14 @@
 package binary
 
 import (
+	"math/rand"
 	"testing"
 )
 
 type Int int
 
+var _ OrderedSet = New()
+
 // Returns -1 if other is not a comparable
 func (i Int) CompareTo(other Comparable) int {
 	o, ok := other.(Int)
@@ -79,9 +82,9 @@ func TestDelete(t *testing.T) {
 		tree.Insert(Int(i))
 	}
 	for i := 0; i < 10; i++ {
-		returned := tree.Delete(Int(i)) // deletes root each time
-		if (*returned).(Int) != Int(i) {
-			t.Errorf("returned value %v != expected %v", *returned, i)
+		returned, ok := tree.Delete(Int(i)) // deletes root each time
+		if !ok || returned.(Int) != Int(i) {
+			t.Errorf("returned value %v != expected %v", returned, i)
 		}
 		verify(tree.root, t)
 	}
@@ -90,9 +93,9 @@ func TestDelete(t *testing.T) {
 		tree.Insert(Int(i))
 	}
 	for i := 10; i >= 0; i-- {
-		returned := tree.Delete(Int(i))
-		if (*returned).(Int) != Int(i) {
-			t.Errorf("returned value %v != expected %v", *returned, i)
+		returned, ok := tree.Delete(Int(i))
+		if !ok || returned.(Int) != Int(i) {
+			t.Errorf("returned value %v != expected %v", returned, i)
 		}
 		verify(tree.root, t)
 	}
@@ -165,7 +168,7 @@ func TestWalkPostOrder(t *testing.T) {
 	}
 	tree.Insert(Int(9))
 	verify(tree.root, t)
-	walked := tree.Walk()
+	walked := tree.WalkPostOrder()
 	for i := 0; i < len(walked)-1; i++ {
 		if walked[i].(Int).CompareTo(walked[i+1]) == -1 {
 			t.Errorf("Post order walk out of order results: %v after %v", walked[i], walked[i+1])
@@ -188,3 +191,130 @@ func TestWalkPreOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestEmpty(t *testing.T) {
+	tree := New()
+	if _, ok := tree.Minimum(); ok {
+		t.Errorf("Minimum of empty tree returned ok")
+	}
+	if _, ok := tree.Maximum(); ok {
+		t.Errorf("Maximum of empty tree returned ok")
+	}
+	if _, ok := tree.Successor(Int(0)); ok {
+		t.Errorf("Successor in empty tree returned ok")
+	}
+	if _, ok := tree.Delete(Int(0)); ok {
+		t.Errorf("Delete from empty tree returned ok")
+	}
+	if tree.Contains(Int(0)) || tree.Len() != 0 || tree.Height() != 0 || len(tree.Walk()) != 0 {
+		t.Errorf("empty tree is not empty")
+	}
+}
+
+func TestLenClearHeight(t *testing.T) {
+	tree := New()
+	for i := 0; i < 10; i++ {
+		tree.Insert(Int(i))
+	}
+	if tree.Len() != 10 || tree.Height() != 10 {
+		t.Errorf("Len, Height = %v, %v; want 10, 10", tree.Len(), tree.Height())
+	}
+	tree.Delete(Int(4))
+	if tree.Len() != 9 || len(tree.Walk()) != 9 {
+		t.Errorf("Len after delete = %v, walked %v; want 9", tree.Len(), len(tree.Walk()))
+	}
+	tree.Clear()
+	if tree.Len() != 0 || tree.Height() != 0 || tree.Contains(Int(1)) {
+		t.Errorf("tree not empty after Clear")
+	}
+}
+
+// The sorted slice model of a binary tree.
+type model []int
+
+func (m model) index(v int) int {
+	i := 0
+	for i < len(m) && m[i] < v {
+		i++
+	}
+	return i
+}
+func (m *model) insert(v int) {
+	i := m.index(v)
+	*m = append(*m, 0)
+	copy((*m)[i+1:], (*m)[i:])
+	(*m)[i] = v
+}
+func (m model) contains(v int) bool {
+	i := m.index(v)
+	return i < len(m) && m[i] == v
+}
+func (m *model) delete(v int) bool {
+	if !m.contains(v) {
+		return false
+	}
+	i := m.index(v)
+	*m = append((*m)[:i], (*m)[i+1:]...)
+	return true
+}
+func (m model) successor(v int) (int, bool) {
+	if !m.contains(v) {
+		return 0, false
+	}
+	for _, s := range m {
+		if s > v {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+func TestAgainstModel(t *testing.T) {
+	r := rand.New(rand.NewSource(27))
+	for run := 0; run < 50; run++ {
+		tree := New()
+		var m model
+		for op := 0; op < 200; op++ {
+			v := r.Intn(30)
+			if r.Intn(3) == 0 {
+				got, ok := tree.Delete(Int(v))
+				if want := m.delete(v); ok != want || ok && got.(Int) != Int(v) {
+					t.Fatalf("Delete(%v) = %v, %v; want %v", v, got, ok, want)
+				}
+			} else {
+				tree.Insert(Int(v))
+				m.insert(v)
+			}
+			verify(tree.root, t)
+			if tree.Len() != len(m) {
+				t.Fatalf("Len = %v; want %v", tree.Len(), len(m))
+			}
+			v = r.Intn(30)
+			if got, want := tree.Contains(Int(v)), m.contains(v); got != want {
+				t.Fatalf("Contains(%v) = %v; want %v", v, got, want)
+			}
+			got, ok := tree.Successor(Int(v))
+			want, wantOk := m.successor(v)
+			if ok != wantOk || ok && got.(Int) != Int(want) {
+				t.Fatalf("Successor(%v) = %v, %v; want %v, %v", v, got, ok, want, wantOk)
+			}
+			min, ok := tree.Minimum()
+			if ok != (len(m) > 0) || ok && min.(Int) != Int(m[0]) {
+				t.Fatalf("Minimum = %v, %v; model %v", min, ok, m)
+			}
+			max, ok := tree.Maximum()
+			if ok != (len(m) > 0) || ok && max.(Int) != Int(m[len(m)-1]) {
+				t.Fatalf("Maximum = %v, %v; model %v", max, ok, m)
+			}
+		}
+		walked := tree.Walk()
+		if len(walked) != len(m) {
+			t.Fatalf("walked %v values; want %v", len(walked), len(m))
+		}
+		for i := range walked {
+			if walked[i].(Int) != Int(m[i]) {
+				t.Fatalf("Walk = %v; want %v", walked, m)
+			}
+		}
+	}
+}
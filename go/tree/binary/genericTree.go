@@ -0,0 +1,244 @@
+package binary
+
+// Tree is a binary tree parameterized over its element type.
+// Unlike BinaryTree, elements do not need to implement Comparable;
+// instead, the tree is ordered by the comparison function it is created with.
+type Tree[T any] struct {
+	root *treeNode[T]
+	size int
+	cmp  func(a, b T) int
+}
+
+type treeNode[T any] struct {
+	parent *treeNode[T]
+	left   *treeNode[T]
+	right  *treeNode[T]
+	value  T
+}
+
+// NewFunc returns a new, empty binary tree ordered by cmp.
+// cmp must return a negative number if a < b, zero if a == b
+// and a positive number if a > b, so cmp.Compare can be used
+// directly for ordered types.
+func NewFunc[T any](cmp func(a, b T) int) *Tree[T] {
+	return &Tree[T]{cmp: cmp}
+}
+
+// Len returns the number of values in the tree.
+func (t *Tree[T]) Len() int {
+	return t.size
+}
+
+// Clear removes all values from the tree.
+func (t *Tree[T]) Clear() {
+	t.root = nil
+	t.size = 0
+}
+
+func treeHeight[T any](n *treeNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return max(treeHeight(n.left), treeHeight(n.right)) + 1
+}
+
+// Height returns the number of nodes on the longest path from the root
+// to a leaf. An empty tree has height 0.
+func (t *Tree[T]) Height() int {
+	return treeHeight(t.root)
+}
+
+func treeWalkInOrder[T any](n *treeNode[T], walked []T) []T {
+	if n != nil {
+		walked = treeWalkInOrder(n.left, walked)
+		walked = append(walked, n.value)
+		walked = treeWalkInOrder(n.right, walked)
+	}
+	return walked
+}
+
+// Walk returns an in order slice of the values in the tree.
+func (t *Tree[T]) Walk() []T {
+	return treeWalkInOrder(t.root, make([]T, 0, t.size))
+}
+
+func treeWalkPreOrder[T any](n *treeNode[T], walked []T) []T {
+	if n != nil {
+		walked = append(walked, n.value)
+		walked = treeWalkPreOrder(n.left, walked)
+		walked = treeWalkPreOrder(n.right, walked)
+	}
+	return walked
+}
+
+// WalkPreOrder returns a pre order slice of the values in the tree.
+func (t *Tree[T]) WalkPreOrder() []T {
+	return treeWalkPreOrder(t.root, make([]T, 0, t.size))
+}
+
+func treeWalkPostOrder[T any](n *treeNode[T], walked []T) []T {
+	if n != nil {
+		walked = treeWalkPostOrder(n.left, walked)
+		walked = treeWalkPostOrder(n.right, walked)
+		walked = append(walked, n.value)
+	}
+	return walked
+}
+
+// WalkPostOrder returns a post order slice of the values in the tree.
+func (t *Tree[T]) WalkPostOrder() []T {
+	return treeWalkPostOrder(t.root, make([]T, 0, t.size))
+}
+
+// Returns the node containing target, or nil if no node does.
+func (t *Tree[T]) search(target T) *treeNode[T] {
+	current := t.root
+	for current != nil {
+		c := t.cmp(target, current.value)
+		switch {
+		case c < 0:
+			current = current.left
+		case c > 0:
+			current = current.right
+		default:
+			return current
+		}
+	}
+	return nil
+}
+
+// Contains returns true if the tree contains target.
+func (t *Tree[T]) Contains(target T) bool {
+	return t.search(target) != nil
+}
+
+func treeMinimum[T any](n *treeNode[T]) *treeNode[T] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func treeMaximum[T any](n *treeNode[T]) *treeNode[T] {
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// Minimum returns the minimum value in the tree.
+// If the tree is empty, ok is false.
+func (t *Tree[T]) Minimum() (min T, ok bool) {
+	if t.root == nil {
+		return min, false
+	}
+	return treeMinimum(t.root).value, true
+}
+
+// Maximum returns the maximum value in the tree.
+// If the tree is empty, ok is false.
+func (t *Tree[T]) Maximum() (max T, ok bool) {
+	if t.root == nil {
+		return max, false
+	}
+	return treeMaximum(t.root).value, true
+}
+
+// Returns the node that follows n in an in order walk, or nil if n is the maximum.
+func treeNext[T any](n *treeNode[T]) *treeNode[T] {
+	if n.right != nil {
+		return treeMinimum(n.right)
+	}
+	parent := n.parent
+	for parent != nil && n == parent.right {
+		n = parent
+		parent = n.parent
+	}
+	return parent
+}
+
+// Successor returns the smallest value in the tree that is greater than target.
+// ok is false if the tree does not contain target or if target is the maximum.
+func (t *Tree[T]) Successor(target T) (succ T, ok bool) {
+	current := t.search(target)
+	if current == nil {
+		return succ, false
+	}
+	for current = treeNext(current); current != nil; current = treeNext(current) {
+		if t.cmp(current.value, target) > 0 {
+			return current.value, true
+		}
+	}
+	return succ, false
+}
+
+// Insert inserts value into the tree. Values equal to ones
+// already in the tree are inserted after them.
+// Expected running time O(lg n), worst case running time O(n)
+// for a tree with n nodes.
+func (t *Tree[T]) Insert(value T) {
+	newNode := &treeNode[T]{value: value}
+	var y *treeNode[T]
+	x := t.root
+	for x != nil {
+		y = x
+		if t.cmp(value, x.value) < 0 {
+			x = x.left
+		} else {
+			x = x.right
+		}
+	}
+	newNode.parent = y
+	if y == nil {
+		t.root = newNode
+	} else if t.cmp(value, y.value) < 0 {
+		y.left = newNode
+	} else {
+		y.right = newNode
+	}
+	t.size++
+}
+
+// See BinaryTree.transplant.
+func (t *Tree[T]) transplant(old, replacement *treeNode[T]) {
+	if old.parent == nil {
+		t.root = replacement
+	} else if old == old.parent.left {
+		old.parent.left = replacement
+	} else {
+		old.parent.right = replacement
+	}
+	if replacement != nil {
+		replacement.parent = old.parent
+	}
+	old.parent = nil
+	old.left = nil
+	old.right = nil
+}
+
+// Delete removes one value equal to value from the tree and returns it.
+// If the tree does not contain value, ok is false.
+// Running time is O(h) for a tree of height h.
+func (t *Tree[T]) Delete(value T) (deleted T, ok bool) {
+	n := t.search(value)
+	if n == nil {
+		return deleted, false
+	}
+	if n.left == nil {
+		t.transplant(n, n.right)
+	} else if n.right == nil {
+		t.transplant(n, n.left)
+	} else {
+		replacement := treeMinimum(n.right)
+		if replacement.parent != n {
+			t.transplant(replacement, replacement.right)
+			replacement.right = n.right
+			replacement.right.parent = replacement
+		}
+		replacement.left = n.left
+		replacement.left.parent = replacement
+		t.transplant(n, replacement)
+	}
+	t.size--
+	return n.value, true
+}
@@ -0,0 +1,196 @@
+package binary
+
+import (
+	"cmp"
+	"math/rand"
+	"testing"
+)
+
+func verifyTree[T any](tree *Tree[T], n *treeNode[T], t *testing.T) {
+	if n != nil {
+		if n.left != nil {
+			if tree.cmp(n.left.value, n.value) > 0 {
+				t.Errorf("Left child %v > parent %v", n.left.value, n.value)
+			}
+			if n.left.parent != n {
+				t.Errorf("Left child %v does not point back to parent %v", n.left.value, n.value)
+			}
+		}
+		if n.right != nil {
+			if tree.cmp(n.right.value, n.value) < 0 {
+				t.Errorf("Right child %v < parent %v", n.right.value, n.value)
+			}
+			if n.right.parent != n {
+				t.Errorf("Right child %v does not point back to parent %v", n.right.value, n.value)
+			}
+		}
+		verifyTree(tree, n.left, t)
+		verifyTree(tree, n.right, t)
+	}
+}
+
+func TestTreeInsertDelete(t *testing.T) {
+	tree := NewFunc(cmp.Compare[int])
+	in := []int{5, 3, 2, 3, 0, 1, 4, 8, 6, 9, 5, 7}
+	for _, v := range in {
+		tree.Insert(v)
+		verifyTree(tree, tree.root, t)
+	}
+	walked := tree.Walk()
+	if len(walked) != len(in) {
+		t.Errorf("walked %v values, want %v", len(walked), len(in))
+	}
+	for i := 0; i < len(walked)-1; i++ {
+		if walked[i] > walked[i+1] {
+			t.Errorf("In order walk out of order results: %v before %v", walked[i], walked[i+1])
+		}
+	}
+	for _, v := range in {
+		got, ok := tree.Delete(v)
+		if !ok || got != v {
+			t.Errorf("Delete(%v) = %v, %v; want %v, true", v, got, ok, v)
+		}
+		verifyTree(tree, tree.root, t)
+	}
+	if _, ok := tree.Delete(5); ok {
+		t.Errorf("Delete on empty tree returned ok")
+	}
+}
+
+func TestTreeQueries(t *testing.T) {
+	tree := NewFunc(cmp.Compare[int])
+	if _, ok := tree.Minimum(); ok {
+		t.Errorf("Minimum of empty tree returned ok")
+	}
+	if _, ok := tree.Maximum(); ok {
+		t.Errorf("Maximum of empty tree returned ok")
+	}
+	for _, v := range []int{5, 3, 2, 3, 0, 1, 4, 8, 6, 9, 5, 7} {
+		tree.Insert(v)
+	}
+	if min, ok := tree.Minimum(); !ok || min != 0 {
+		t.Errorf("Minimum = %v, %v; want 0, true", min, ok)
+	}
+	if max, ok := tree.Maximum(); !ok || max != 9 {
+		t.Errorf("Maximum = %v, %v; want 9, true", max, ok)
+	}
+	if !tree.Contains(7) || tree.Contains(10) {
+		t.Errorf("Contains reported wrong membership")
+	}
+	tests := []struct {
+		In, Want int
+		WantOk   bool
+	}{
+		{0, 1, true},
+		{3, 4, true},
+		{5, 6, true},
+		{4, 5, true},
+		{9, 0, false},
+		{10, 0, false},
+	}
+	for _, test := range tests {
+		got, ok := tree.Successor(test.In)
+		if ok != test.WantOk || got != test.Want {
+			t.Errorf("Successor(%v) = %v, %v; want %v, %v", test.In, got, ok, test.Want, test.WantOk)
+		}
+	}
+}
+
+func benchmarkInts(n int) []int {
+	r := rand.New(rand.NewSource(1))
+	return r.Perm(n)
+}
+
+func BenchmarkBinaryTreeInsert(b *testing.B) {
+	ints := benchmarkInts(1 << 12)
+	for i := 0; i < b.N; i++ {
+		tree := New()
+		for _, v := range ints {
+			tree.Insert(Int(v))
+		}
+	}
+}
+
+func BenchmarkTreeInsert(b *testing.B) {
+	ints := benchmarkInts(1 << 12)
+	for i := 0; i < b.N; i++ {
+		tree := NewFunc(cmp.Compare[int])
+		for _, v := range ints {
+			tree.Insert(v)
+		}
+	}
+}
+
+func BenchmarkBinaryTreeWalk(b *testing.B) {
+	tree := New()
+	for _, v := range benchmarkInts(1 << 12) {
+		tree.Insert(Int(v))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Walk()
+	}
+}
+
+func BenchmarkTreeWalk(b *testing.B) {
+	tree := NewFunc(cmp.Compare[int])
+	for _, v := range benchmarkInts(1 << 12) {
+		tree.Insert(v)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Walk()
+	}
+}
+
+func TestTreeAgainstModel(t *testing.T) {
+	r := rand.New(rand.NewSource(27))
+	for run := 0; run < 50; run++ {
+		tree := NewFunc(cmp.Compare[int])
+		var m model
+		for op := 0; op < 200; op++ {
+			v := r.Intn(30)
+			if r.Intn(3) == 0 {
+				got, ok := tree.Delete(v)
+				if want := m.delete(v); ok != want || ok && got != v {
+					t.Fatalf("Delete(%v) = %v, %v; want %v", v, got, ok, want)
+				}
+			} else {
+				tree.Insert(v)
+				m.insert(v)
+			}
+			verifyTree(tree, tree.root, t)
+			if tree.Len() != len(m) {
+				t.Fatalf("Len = %v; want %v", tree.Len(), len(m))
+			}
+			v = r.Intn(30)
+			if got, want := tree.Contains(v), m.contains(v); got != want {
+				t.Fatalf("Contains(%v) = %v; want %v", v, got, want)
+			}
+			got, ok := tree.Successor(v)
+			want, wantOk := m.successor(v)
+			if ok != wantOk || got != want {
+				t.Fatalf("Successor(%v) = %v, %v; want %v, %v", v, got, ok, want, wantOk)
+			}
+			if min, ok := tree.Minimum(); ok != (len(m) > 0) || ok && min != m[0] {
+				t.Fatalf("Minimum = %v, %v; model %v", min, ok, m)
+			}
+			if max, ok := tree.Maximum(); ok != (len(m) > 0) || ok && max != m[len(m)-1] {
+				t.Fatalf("Maximum = %v, %v; model %v", max, ok, m)
+			}
+		}
+		walked := tree.Walk()
+		if len(walked) != len(m) {
+			t.Fatalf("walked %v values; want %v", len(walked), len(m))
+		}
+		for i := range walked {
+			if walked[i] != m[i] {
+				t.Fatalf("Walk = %v; want %v", walked, m)
+			}
+		}
+		tree.Clear()
+		if tree.Len() != 0 || tree.Height() != 0 {
+			t.Fatalf("tree not empty after Clear")
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package binary
+
+// Persistent is an immutable binary tree. Insert and Delete do not modify
+// the tree they are called on; they return a new version of the tree that
+// shares every node not on the path to the changed value. Because no version
+// is ever modified, any version can be read from many goroutines without
+// locking while a writer keeps creating new versions.
+type Persistent struct {
+	root *pnode
+	size int
+}
+
+type pnode struct {
+	left  *pnode
+	right *pnode
+	value Comparable
+}
+
+// Returns a new, empty persistent binary tree.
+func NewPersistent() *Persistent {
+	return &Persistent{}
+}
+
+// Len returns the number of values in this version of the tree.
+func (p *Persistent) Len() int {
+	return p.size
+}
+
+func pwalkInOrder(node *pnode, walked []Comparable) []Comparable {
+	if node != nil {
+		walked = pwalkInOrder(node.left, walked)
+		walked = append(walked, node.value)
+		walked = pwalkInOrder(node.right, walked)
+	}
+	return walked
+}
+
+// Returns an in order Comparable slice of this version of the tree.
+func (p *Persistent) Walk() []Comparable {
+	walked := make([]Comparable, 0, p.size)
+	return pwalkInOrder(p.root, walked)
+}
+
+// Returns true if this version of the tree contains the target Comparable.
+func (p *Persistent) Contains(target Comparable) bool {
+	current := p.root
+	for current != nil {
+		c := target.CompareTo(current.value)
+		switch {
+		case c < 0:
+			current = current.left
+		case c > 0:
+			current = current.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the minimum value in this version of the tree.
+// If the tree is empty, ok is false.
+func (p *Persistent) Minimum() (min Comparable, ok bool) {
+	current := p.root
+	if current == nil {
+		return nil, false
+	}
+	for current.left != nil {
+		current = current.left
+	}
+	return current.value, true
+}
+
+// Returns the maximum value in this version of the tree.
+// If the tree is empty, ok is false.
+func (p *Persistent) Maximum() (max Comparable, ok bool) {
+	current := p.root
+	if current == nil {
+		return nil, false
+	}
+	for current.right != nil {
+		current = current.right
+	}
+	return current.value, true
+}
+
+// Returns the smallest value in this version of the tree that is greater than target.
+// ok is false if the tree does not contain the target or if there is no successor.
+//
+// Nodes do not know their parents (a parent would have to be copied
+// along with every child), so this walks down from the root remembering
+// the last node it turned left at.
+func (p *Persistent) Successor(target Comparable) (succ Comparable, ok bool) {
+	found := false
+	current := p.root
+	for current != nil {
+		c := target.CompareTo(current.value)
+		if c < 0 {
+			succ, ok = current.value, true
+			current = current.left
+		} else {
+			found = found || c == 0
+			current = current.right
+		}
+	}
+	if !found {
+		return nil, false
+	}
+	return succ, ok
+}
+
+func pinsert(node *pnode, value Comparable) *pnode {
+	if node == nil {
+		return &pnode{value: value}
+	}
+	copied := *node
+	if value.CompareTo(node.value) < 0 {
+		copied.left = pinsert(node.left, value)
+	} else {
+		copied.right = pinsert(node.right, value)
+	}
+	return &copied
+}
+
+// Insert returns a new version of the tree that contains value.
+// Only the O(h) nodes on the path to the new value are copied.
+func (p *Persistent) Insert(value Comparable) *Persistent {
+	return &Persistent{root: pinsert(p.root, value), size: p.size + 1}
+}
+
+// Returns a copy of node's subtree with its minimum removed, along with that minimum.
+func pdeleteMin(node *pnode) (*pnode, Comparable) {
+	if node.left == nil {
+		return node.right, node.value
+	}
+	copied := *node
+	var min Comparable
+	copied.left, min = pdeleteMin(node.left)
+	return &copied, min
+}
+
+func pdelete(node *pnode, value Comparable) (*pnode, bool) {
+	if node == nil {
+		return nil, false
+	}
+	copied := *node
+	deleted := false
+	c := value.CompareTo(node.value)
+	switch {
+	case c < 0:
+		copied.left, deleted = pdelete(node.left, value)
+	case c > 0:
+		copied.right, deleted = pdelete(node.right, value)
+	default:
+		if node.left == nil {
+			return node.right, true
+		}
+		if node.right == nil {
+			return node.left, true
+		}
+		copied.right, copied.value = pdeleteMin(node.right)
+		return &copied, true
+	}
+	if !deleted {
+		return node, false
+	}
+	return &copied, true
+}
+
+// Delete returns a new version of the tree with one value equal to value removed.
+// If this version does not contain value, it is returned unchanged and ok is false.
+func (p *Persistent) Delete(value Comparable) (*Persistent, bool) {
+	root, ok := pdelete(p.root, value)
+	if !ok {
+		return p, false
+	}
+	return &Persistent{root: root, size: p.size - 1}, true
+}
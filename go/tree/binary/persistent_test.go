@@ -0,0 +1,98 @@
+package binary
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func verifyPersistent(p *Persistent, t *testing.T, want model) {
+	walked := p.Walk()
+	if len(walked) != len(want) || p.Len() != len(want) {
+		t.Fatalf("version has %v values, Len %v; want %v", len(walked), p.Len(), want)
+	}
+	for i := range walked {
+		if walked[i].(Int) != Int(want[i]) {
+			t.Fatalf("Walk = %v; want %v", walked, want)
+		}
+	}
+}
+
+func TestPersistentVersions(t *testing.T) {
+	r := rand.New(rand.NewSource(28))
+	versions := []*Persistent{NewPersistent()}
+	models := []model{nil}
+	for op := 0; op < 300; op++ {
+		last := versions[len(versions)-1]
+		m := append(model(nil), models[len(models)-1]...)
+		v := r.Intn(40)
+		next := last
+		if r.Intn(3) == 0 {
+			var ok bool
+			next, ok = last.Delete(Int(v))
+			if want := m.delete(v); ok != want {
+				t.Fatalf("Delete(%v) ok = %v; want %v", v, ok, want)
+			}
+			if !ok && next != last {
+				t.Fatalf("failed Delete(%v) returned a new version", v)
+			}
+		} else {
+			next = last.Insert(Int(v))
+			m.insert(v)
+		}
+		versions = append(versions, next)
+		models = append(models, m)
+
+		v = r.Intn(40)
+		if got, want := next.Contains(Int(v)), m.contains(v); got != want {
+			t.Fatalf("Contains(%v) = %v; want %v", v, got, want)
+		}
+		got, ok := next.Successor(Int(v))
+		want, wantOk := m.successor(v)
+		if ok != wantOk || ok && got.(Int) != Int(want) {
+			t.Fatalf("Successor(%v) = %v, %v; want %v, %v", v, got, ok, want, wantOk)
+		}
+		if min, ok := next.Minimum(); ok != (len(m) > 0) || ok && min.(Int) != Int(m[0]) {
+			t.Fatalf("Minimum = %v, %v; model %v", min, ok, m)
+		}
+		if max, ok := next.Maximum(); ok != (len(m) > 0) || ok && max.(Int) != Int(m[len(m)-1]) {
+			t.Fatalf("Maximum = %v, %v; model %v", max, ok, m)
+		}
+	}
+	// every old version must be untouched by later writes
+	for i := range versions {
+		verifyPersistent(versions[i], t, models[i])
+	}
+}
+
+func TestPersistentConcurrentReaders(t *testing.T) {
+	snapshots := make(chan *Persistent)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for snap := range snapshots {
+				walked := snap.Walk()
+				for j := range walked {
+					if !snap.Contains(walked[j]) {
+						t.Errorf("snapshot does not contain walked value %v", walked[j])
+					}
+					if j > 0 && walked[j-1].CompareTo(walked[j]) > 0 {
+						t.Errorf("snapshot walk out of order: %v before %v", walked[j-1], walked[j])
+					}
+				}
+			}
+		}()
+	}
+	tree := NewPersistent()
+	for i := 0; i < 200; i++ {
+		tree = tree.Insert(Int((i * 7) % 50))
+		if i%3 == 0 {
+			tree, _ = tree.Delete(Int(i % 50))
+		}
+		snapshots <- tree
+	}
+	close(snapshots)
+	wg.Wait()
+}
@@ -0,0 +1,385 @@
+// Package btree implements a B-tree of binary.Comparable values.
+// Each node holds many values in a slice, so a B-tree of minimum degree t
+// has height O(log_t n) and touches far fewer nodes per operation than a
+// binary tree. That makes it much friendlier to the cache for large sets.
+package btree
+
+import (
+	"errors"
+	"github.com/twmb/algoimpl/go/tree/binary"
+	"sort"
+)
+
+// BTree is an ordered set of binary.Comparable values.
+// It implements binary.OrderedSet.
+//
+// Every node other than the root holds between degree-1 and 2*degree-1
+// values, and every leaf is at the same depth.
+type BTree struct {
+	root   *node
+	degree int
+	size   int
+}
+
+type node struct {
+	keys     []binary.Comparable
+	children []*node // nil for leaves
+}
+
+func (n *node) leaf() bool {
+	return n.children == nil
+}
+
+// Returns the first index in n.keys whose value is not less than value.
+func (n *node) lowerBound(value binary.Comparable) int {
+	return sort.Search(len(n.keys), func(i int) bool {
+		return n.keys[i].CompareTo(value) >= 0
+	})
+}
+
+// Returns the first index in n.keys whose value is greater than value.
+func (n *node) upperBound(value binary.Comparable) int {
+	return sort.Search(len(n.keys), func(i int) bool {
+		return n.keys[i].CompareTo(value) > 0
+	})
+}
+
+// New returns a new, empty B-tree with the given minimum degree.
+// If degree is less than 2, 2 is used.
+func New(degree int) *BTree {
+	if degree < 2 {
+		degree = 2
+	}
+	return &BTree{degree: degree}
+}
+
+// Returns the most keys a subtree of the given height (leaves are height 0) can hold.
+func maxKeys(degree, height int) int {
+	m := 2*degree - 1
+	for i := 0; i < height; i++ {
+		m = (m+1)*2*degree - 1
+	}
+	return m
+}
+
+// Builds a subtree of the given height from sorted keys, spreading
+// the keys as evenly as possible over as few children as it can.
+func build(keys []binary.Comparable, degree, height int, root bool) *node {
+	if height == 0 {
+		return &node{keys: append([]binary.Comparable(nil), keys...)}
+	}
+	childMax := maxKeys(degree, height-1)
+	c := (len(keys) + childMax + 1) / (childMax + 1) // ceil((n+1) / (childMax+1))
+	minChildren := degree
+	if root {
+		minChildren = 2
+	}
+	if c < minChildren {
+		c = minChildren
+	}
+	rest := len(keys) - (c - 1) // keys left for children after separators
+	n := &node{keys: make([]binary.Comparable, 0, c-1), children: make([]*node, 0, c)}
+	pos := 0
+	for j := 0; j < c; j++ {
+		count := rest / c
+		if j < rest%c {
+			count++
+		}
+		n.children = append(n.children, build(keys[pos:pos+count], degree, height-1, false))
+		pos += count
+		if j < c-1 {
+			n.keys = append(n.keys, keys[pos])
+			pos++
+		}
+	}
+	return n
+}
+
+// NewFromSorted bulk loads a B-tree with the given minimum degree from
+// values that are already in ascending order, in O(n) time.
+// It returns an error if sorted is not sorted.
+func NewFromSorted(degree int, sorted []binary.Comparable) (*BTree, error) {
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i-1].CompareTo(sorted[i]) > 0 {
+			return nil, errors.New("NewFromSorted: values are not sorted")
+		}
+	}
+	b := New(degree)
+	if len(sorted) == 0 {
+		return b, nil
+	}
+	height := 0
+	for maxKeys(b.degree, height) < len(sorted) {
+		height++
+	}
+	b.root = build(sorted, b.degree, height, true)
+	b.size = len(sorted)
+	return b, nil
+}
+
+// Len returns the number of values in the B-tree.
+func (b *BTree) Len() int {
+	return b.size
+}
+
+// Returns true if the B-tree contains the target Comparable.
+func (b *BTree) Contains(target binary.Comparable) bool {
+	n := b.root
+	for n != nil {
+		i := n.lowerBound(target)
+		if i < len(n.keys) && n.keys[i].CompareTo(target) == 0 {
+			return true
+		}
+		if n.leaf() {
+			return false
+		}
+		n = n.children[i]
+	}
+	return false
+}
+
+func minimum(n *node) binary.Comparable {
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return n.keys[0]
+}
+
+func maximum(n *node) binary.Comparable {
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return n.keys[len(n.keys)-1]
+}
+
+// Returns the minimum value in the B-tree.
+// If the B-tree is empty, ok is false.
+func (b *BTree) Minimum() (min binary.Comparable, ok bool) {
+	if b.root == nil {
+		return nil, false
+	}
+	return minimum(b.root), true
+}
+
+// Returns the maximum value in the B-tree.
+// If the B-tree is empty, ok is false.
+func (b *BTree) Maximum() (max binary.Comparable, ok bool) {
+	if b.root == nil {
+		return nil, false
+	}
+	return maximum(b.root), true
+}
+
+// Returns the smallest value in the B-tree that is greater than target.
+// ok is false if the B-tree does not contain the target or if there is no successor.
+func (b *BTree) Successor(target binary.Comparable) (succ binary.Comparable, ok bool) {
+	if !b.Contains(target) {
+		return nil, false
+	}
+	for n := b.root; n != nil; {
+		i := n.upperBound(target)
+		if i < len(n.keys) {
+			succ, ok = n.keys[i], true
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	return succ, ok
+}
+
+func walkInOrder(n *node, walked []binary.Comparable) []binary.Comparable {
+	if n.leaf() {
+		return append(walked, n.keys...)
+	}
+	for i := range n.keys {
+		walked = walkInOrder(n.children[i], walked)
+		walked = append(walked, n.keys[i])
+	}
+	return walkInOrder(n.children[len(n.keys)], walked)
+}
+
+// Returns an in order Comparable slice of the B-tree.
+func (b *BTree) Walk() []binary.Comparable {
+	walked := make([]binary.Comparable, 0, b.size)
+	if b.root == nil {
+		return walked
+	}
+	return walkInOrder(b.root, walked)
+}
+
+// Splits the full child at index i of n around its median, moving the median up into n.
+func (n *node) splitChild(i, degree int) {
+	full := n.children[i]
+	right := &node{keys: append(make([]binary.Comparable, 0, 2*degree-1), full.keys[degree:]...)}
+	if !full.leaf() {
+		right.children = append(make([]*node, 0, 2*degree), full.children[degree:]...)
+		clear(full.children[degree:])
+		full.children = full.children[:degree]
+	}
+	median := full.keys[degree-1]
+	clear(full.keys[degree-1:]) // garbage collect
+	full.keys = full.keys[:degree-1]
+
+	n.keys = append(n.keys, nil)
+	copy(n.keys[i+1:], n.keys[i:])
+	n.keys[i] = median
+	n.children = append(n.children, nil)
+	copy(n.children[i+2:], n.children[i+1:])
+	n.children[i+1] = right
+}
+
+func (n *node) insertNonFull(value binary.Comparable, degree int) {
+	for {
+		i := n.upperBound(value)
+		if n.leaf() {
+			n.keys = append(n.keys, nil)
+			copy(n.keys[i+1:], n.keys[i:])
+			n.keys[i] = value
+			return
+		}
+		if len(n.children[i].keys) == 2*degree-1 {
+			n.splitChild(i, degree)
+			if n.keys[i].CompareTo(value) <= 0 {
+				i++
+			}
+		}
+		n = n.children[i]
+	}
+}
+
+// Insert inserts value into the B-tree after any equal values.
+// Running time is O(t log_t n) for minimum degree t.
+func (b *BTree) Insert(value binary.Comparable) {
+	b.size++
+	if b.root == nil {
+		b.root = &node{keys: append(make([]binary.Comparable, 0, 2*b.degree-1), value)}
+		return
+	}
+	if len(b.root.keys) == 2*b.degree-1 {
+		b.root = &node{children: []*node{b.root}}
+		b.root.splitChild(0, b.degree)
+	}
+	b.root.insertNonFull(value, b.degree)
+}
+
+// Merges the child at i+1 and the key at i into the child at i.
+func (n *node) mergeChildren(i int) {
+	left, right := n.children[i], n.children[i+1]
+	left.keys = append(left.keys, n.keys[i])
+	left.keys = append(left.keys, right.keys...)
+	if !left.leaf() {
+		left.children = append(left.children, right.children...)
+	}
+	copy(n.keys[i:], n.keys[i+1:])
+	n.keys[len(n.keys)-1] = nil
+	n.keys = n.keys[:len(n.keys)-1]
+	copy(n.children[i+1:], n.children[i+2:])
+	n.children[len(n.children)-1] = nil
+	n.children = n.children[:len(n.children)-1]
+}
+
+// Makes sure the child at i has at least degree keys so that a key
+// can be deleted from it, and returns the index the child ends up at.
+func (n *node) fill(i, degree int) int {
+	child := n.children[i]
+	if len(child.keys) >= degree {
+		return i
+	}
+	if i > 0 && len(n.children[i-1].keys) >= degree { // borrow from the left
+		left := n.children[i-1]
+		child.keys = append(child.keys, nil)
+		copy(child.keys[1:], child.keys)
+		child.keys[0] = n.keys[i-1]
+		n.keys[i-1] = left.keys[len(left.keys)-1]
+		left.keys[len(left.keys)-1] = nil
+		left.keys = left.keys[:len(left.keys)-1]
+		if !child.leaf() {
+			child.children = append(child.children, nil)
+			copy(child.children[1:], child.children)
+			child.children[0] = left.children[len(left.children)-1]
+			left.children[len(left.children)-1] = nil
+			left.children = left.children[:len(left.children)-1]
+		}
+		return i
+	}
+	if i < len(n.keys) && len(n.children[i+1].keys) >= degree { // borrow from the right
+		right := n.children[i+1]
+		child.keys = append(child.keys, n.keys[i])
+		n.keys[i] = right.keys[0]
+		copy(right.keys, right.keys[1:])
+		right.keys[len(right.keys)-1] = nil
+		right.keys = right.keys[:len(right.keys)-1]
+		if !child.leaf() {
+			child.children = append(child.children, right.children[0])
+			copy(right.children, right.children[1:])
+			right.children[len(right.children)-1] = nil
+			right.children = right.children[:len(right.children)-1]
+		}
+		return i
+	}
+	if i < len(n.keys) {
+		n.mergeChildren(i)
+		return i
+	}
+	n.mergeChildren(i - 1)
+	return i - 1
+}
+
+// Deletes one value equal to value from the subtree at n,
+// which must have at least degree keys unless it is the root.
+func (n *node) delete(value binary.Comparable, degree int) (binary.Comparable, bool) {
+	for {
+		i := n.lowerBound(value)
+		if i < len(n.keys) && n.keys[i].CompareTo(value) == 0 {
+			deleted := n.keys[i]
+			if n.leaf() {
+				copy(n.keys[i:], n.keys[i+1:])
+				n.keys[len(n.keys)-1] = nil
+				n.keys = n.keys[:len(n.keys)-1]
+				return deleted, true
+			}
+			if len(n.children[i].keys) >= degree { // replace with predecessor
+				pred := maximum(n.children[i])
+				n.keys[i] = pred
+				n.children[i].delete(pred, degree)
+				return deleted, true
+			}
+			if len(n.children[i+1].keys) >= degree { // replace with successor
+				succ := minimum(n.children[i+1])
+				n.keys[i] = succ
+				n.children[i+1].delete(succ, degree)
+				return deleted, true
+			}
+			n.mergeChildren(i)
+			n = n.children[i]
+			continue
+		}
+		if n.leaf() {
+			return nil, false
+		}
+		n = n.children[n.fill(i, degree)]
+	}
+}
+
+// Delete removes one value equal to value from the B-tree and returns it.
+// If the B-tree does not contain value, ok is false.
+// Running time is O(t log_t n) for minimum degree t.
+func (b *BTree) Delete(value binary.Comparable) (deleted binary.Comparable, ok bool) {
+	if b.root == nil {
+		return nil, false
+	}
+	deleted, ok = b.root.delete(value, b.degree)
+	if len(b.root.keys) == 0 {
+		if b.root.leaf() {
+			b.root = nil
+		} else {
+			b.root = b.root.children[0]
+		}
+	}
+	if ok {
+		b.size--
+	}
+	return deleted, ok
+}
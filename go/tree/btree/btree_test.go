@@ -0,0 +1,164 @@
+package btree
+
+import (
+	"github.com/twmb/algoimpl/go/tree/binary"
+	"github.com/twmb/algoimpl/go/tree/internal/settest"
+	"math/rand"
+	"testing"
+)
+
+type Int = settest.Int
+
+var _ binary.OrderedSet = New(2)
+
+// verify checks the B-tree invariants and returns the depth of the leaves.
+func (b *BTree) verify(n *node, t *testing.T, root bool) int {
+	if !root && (len(n.keys) < b.degree-1 || len(n.keys) > 2*b.degree-1) {
+		t.Fatalf("node has %v keys; degree is %v", len(n.keys), b.degree)
+	}
+	for i := 1; i < len(n.keys); i++ {
+		if n.keys[i-1].CompareTo(n.keys[i]) > 0 {
+			t.Fatalf("keys out of order: %v", n.keys)
+		}
+	}
+	if n.leaf() {
+		return 0
+	}
+	if len(n.children) != len(n.keys)+1 {
+		t.Fatalf("node has %v keys and %v children", len(n.keys), len(n.children))
+	}
+	depth := -1
+	for i, child := range n.children {
+		if i > 0 && minimum(child).CompareTo(n.keys[i-1]) < 0 {
+			t.Fatalf("child %v minimum %v < separator %v", i, minimum(child), n.keys[i-1])
+		}
+		if i < len(n.keys) && maximum(child).CompareTo(n.keys[i]) > 0 {
+			t.Fatalf("child %v maximum %v > separator %v", i, maximum(child), n.keys[i])
+		}
+		d := b.verify(child, t, false)
+		if depth != -1 && d != depth {
+			t.Fatalf("leaves at depths %v and %v", depth, d+1)
+		}
+		depth = d
+	}
+	return depth + 1
+}
+
+func (b *BTree) check(t *testing.T, model []int) {
+	if b.root != nil {
+		b.verify(b.root, t, true)
+	}
+	if b.Len() != len(model) {
+		t.Fatalf("Len = %v; want %v", b.Len(), len(model))
+	}
+	walked := b.Walk()
+	if len(walked) != len(model) {
+		t.Fatalf("walked %v values; want %v", len(walked), len(model))
+	}
+	for i := range walked {
+		if int(walked[i].(Int)) != model[i] {
+			t.Fatalf("Walk = %v; want %v", walked, model)
+		}
+	}
+}
+
+func TestAgainstModel(t *testing.T) {
+	for _, degree := range []int{2, 3, 5} {
+		b := New(degree)
+		settest.Model{Seed: 30, Ops: 3000, Values: 200, Verify: func(t *testing.T) {
+			if b.root != nil {
+				b.verify(b.root, t, true)
+			}
+		}}.Run(t, b)
+	}
+}
+
+func TestNewFromSorted(t *testing.T) {
+	for _, degree := range []int{2, 3, 4, 16} {
+		for n := 0; n < 300; n++ {
+			sorted := make([]binary.Comparable, n)
+			model := make([]int, n)
+			for i := range sorted {
+				sorted[i] = Int(i / 2)
+				model[i] = i / 2
+			}
+			b, err := NewFromSorted(degree, sorted)
+			if err != nil {
+				t.Fatalf("NewFromSorted: %v", err)
+			}
+			b.check(t, model)
+			// the loaded tree must keep working
+			b.Insert(Int(n))
+			b.Delete(Int(0))
+			model = append(model, n)[1:]
+			b.check(t, model)
+		}
+	}
+	if _, err := NewFromSorted(2, []binary.Comparable{Int(2), Int(1)}); err == nil {
+		t.Errorf("NewFromSorted on unsorted input did not error")
+	}
+}
+
+func benchmarkValues(n int) []binary.Comparable {
+	r := rand.New(rand.NewSource(1))
+	values := make([]binary.Comparable, n)
+	for i, v := range r.Perm(n) {
+		values[i] = Int(v)
+	}
+	return values
+}
+
+func BenchmarkBinaryTreeInsert(b *testing.B) {
+	values := benchmarkValues(1 << 16)
+	for i := 0; i < b.N; i++ {
+		tree := binary.New()
+		for _, v := range values {
+			tree.Insert(v)
+		}
+	}
+}
+
+func BenchmarkBTreeInsert(b *testing.B) {
+	values := benchmarkValues(1 << 16)
+	for i := 0; i < b.N; i++ {
+		tree := New(32)
+		for _, v := range values {
+			tree.Insert(v)
+		}
+	}
+}
+
+func BenchmarkBinaryTreeContains(b *testing.B) {
+	values := benchmarkValues(1 << 16)
+	tree := binary.New()
+	for _, v := range values {
+		tree.Insert(v)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Contains(values[i%len(values)])
+	}
+}
+
+func BenchmarkBTreeContains(b *testing.B) {
+	values := benchmarkValues(1 << 16)
+	tree := New(32)
+	for _, v := range values {
+		tree.Insert(v)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Contains(values[i%len(values)])
+	}
+}
+
+func BenchmarkBTreeNewFromSorted(b *testing.B) {
+	values := make([]binary.Comparable, 1<<16)
+	for i := range values {
+		values[i] = Int(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewFromSorted(32, values)
+	}
+}
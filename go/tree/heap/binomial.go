@@ -0,0 +1,183 @@
+package heap
+
+// BinomialHeap is a mergeable heap represented as a list of binomial trees,
+// at most one of each size. Insert, Min, ExtractMin, DecreaseKey, Delete
+// and Meld are all O(lg n) in the worst case.
+//
+// The heap is ordered by less: if less(a, b), a is extracted before b.
+// Min and ExtractMin panic if the heap is empty.
+type BinomialHeap[T any] struct {
+	head *binomialTree[T] // roots in increasing order of degree
+	n    int
+	less func(a, b T) bool
+}
+
+// BinomialNode holds a value in a BinomialHeap. It is returned by Insert and
+// is used as a handle for DecreaseKey and Delete. Value must only be
+// changed through DecreaseKey.
+type BinomialNode[T any] struct {
+	Value T
+	tree  *binomialTree[T]
+}
+
+// Values move between tree nodes when they are decreased,
+// so handles point to the node that currently holds them.
+type binomialTree[T any] struct {
+	parent  *binomialTree[T]
+	child   *binomialTree[T] // highest degree child
+	sibling *binomialTree[T]
+	degree  int
+	node    *BinomialNode[T]
+}
+
+// NewBinomial returns an empty binomial heap ordered by less.
+func NewBinomial[T any](less func(a, b T) bool) *BinomialHeap[T] {
+	return &BinomialHeap[T]{less: less}
+}
+
+// Len returns the number of values in the heap.
+func (h *BinomialHeap[T]) Len() int {
+	return h.n
+}
+
+// Makes y the first child of z.
+func (h *BinomialHeap[T]) link(y, z *binomialTree[T]) {
+	y.parent = z
+	y.sibling = z.child
+	z.child = y
+	z.degree++
+}
+
+// Merges two root lists by degree and then links roots
+// of equal degree until every degree appears at most once.
+func (h *BinomialHeap[T]) union(a, b *binomialTree[T]) *binomialTree[T] {
+	var head *binomialTree[T]
+	tail := &head
+	for a != nil && b != nil {
+		if a.degree <= b.degree {
+			*tail, a = a, a.sibling
+		} else {
+			*tail, b = b, b.sibling
+		}
+		tail = &(*tail).sibling
+	}
+	if a != nil {
+		*tail = a
+	} else {
+		*tail = b
+	}
+	if head == nil {
+		return nil
+	}
+	var prev *binomialTree[T]
+	x, next := head, head.sibling
+	for next != nil {
+		if x.degree != next.degree || next.sibling != nil && next.sibling.degree == x.degree {
+			prev, x = x, next
+		} else if !h.less(next.node.Value, x.node.Value) {
+			x.sibling = next.sibling
+			h.link(next, x)
+		} else {
+			if prev == nil {
+				head = next
+			} else {
+				prev.sibling = next
+			}
+			h.link(x, next)
+			x = next
+		}
+		next = x.sibling
+	}
+	return head
+}
+
+// Insert adds value to the heap and returns its node. Complexity is O(lg n).
+func (h *BinomialHeap[T]) Insert(value T) *BinomialNode[T] {
+	n := &BinomialNode[T]{Value: value}
+	n.tree = &binomialTree[T]{node: n}
+	h.head = h.union(h.head, n.tree)
+	h.n++
+	return n
+}
+
+// Returns the root holding the minimum and the root before it.
+func (h *BinomialHeap[T]) minRoot() (min, prev *binomialTree[T]) {
+	min = h.head
+	for p, x := h.head, h.head.sibling; x != nil; p, x = x, x.sibling {
+		if h.less(x.node.Value, min.node.Value) {
+			min, prev = x, p
+		}
+	}
+	return min, prev
+}
+
+// Min returns the value at the top of the heap without removing it.
+// Complexity is O(lg n).
+func (h *BinomialHeap[T]) Min() T {
+	min, _ := h.minRoot()
+	return min.node.Value
+}
+
+// Removes the root x, which follows prev in the root list, and returns its value.
+func (h *BinomialHeap[T]) removeRoot(x, prev *binomialTree[T]) T {
+	if prev == nil {
+		h.head = x.sibling
+	} else {
+		prev.sibling = x.sibling
+	}
+	var children *binomialTree[T] // reversed so degrees increase
+	for c := x.child; c != nil; {
+		next := c.sibling
+		c.parent = nil
+		c.sibling = children
+		children = c
+		c = next
+	}
+	h.head = h.union(h.head, children)
+	h.n--
+	return x.node.Value
+}
+
+// ExtractMin removes and returns the value at the top of the heap.
+// Complexity is O(lg n).
+func (h *BinomialHeap[T]) ExtractMin() T {
+	return h.removeRoot(h.minRoot())
+}
+
+// Moves the value at y up its tree while it sorts before its parent's,
+// or all the way to the root if force is set. Returns the tree node
+// the value ends up at.
+func (h *BinomialHeap[T]) bubbleUp(y *binomialTree[T], force bool) *binomialTree[T] {
+	for z := y.parent; z != nil && (force || h.less(y.node.Value, z.node.Value)); y, z = z, z.parent {
+		y.node, z.node = z.node, y.node
+		y.node.tree = y
+		z.node.tree = z
+	}
+	return y
+}
+
+// DecreaseKey sets n's value to value, which must not be extracted
+// later than n's old value. Complexity is O(lg n).
+func (h *BinomialHeap[T]) DecreaseKey(n *BinomialNode[T], value T) {
+	n.Value = value
+	h.bubbleUp(n.tree, false)
+}
+
+// Delete removes n from the heap and returns its value.
+// Complexity is O(lg n).
+func (h *BinomialHeap[T]) Delete(n *BinomialNode[T]) T {
+	root := h.bubbleUp(n.tree, true)
+	var prev *binomialTree[T]
+	for x := h.head; x != root; x = x.sibling {
+		prev = x
+	}
+	return h.removeRoot(root, prev)
+}
+
+// Meld moves every value in other into h, leaving other empty.
+// Both heaps must use the same ordering. Complexity is O(lg n).
+func (h *BinomialHeap[T]) Meld(other *BinomialHeap[T]) {
+	h.head = h.union(h.head, other.head)
+	h.n += other.n
+	other.head, other.n = nil, 0
+}
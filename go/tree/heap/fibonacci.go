@@ -0,0 +1,219 @@
+package heap
+
+// FibonacciHeap is a mergeable heap represented as a lazily consolidated
+// list of trees. Insert, Min, Meld and DecreaseKey are amortized O(1),
+// while ExtractMin and Delete are amortized O(lg n). The cheap DecreaseKey
+// gives Dijkstra's algorithm its O(E + V lg V) bound.
+//
+// The heap is ordered by less: if less(a, b), a is extracted before b.
+// Min and ExtractMin panic if the heap is empty.
+type FibonacciHeap[T any] struct {
+	min  *FibonacciNode[T] // also the entry into the root list
+	n    int
+	less func(a, b T) bool
+}
+
+// FibonacciNode holds a value in a FibonacciHeap. It is returned by Insert and
+// is used as a handle for DecreaseKey and Delete. Value must only be
+// changed through DecreaseKey.
+type FibonacciNode[T any] struct {
+	Value  T
+	parent *FibonacciNode[T]
+	child  *FibonacciNode[T]
+	left   *FibonacciNode[T] // circular, doubly linked siblings
+	right  *FibonacciNode[T]
+	degree int
+	mark   bool // lost a child since becoming a child itself
+}
+
+// NewFibonacci returns an empty Fibonacci heap ordered by less.
+func NewFibonacci[T any](less func(a, b T) bool) *FibonacciHeap[T] {
+	return &FibonacciHeap[T]{less: less}
+}
+
+// Len returns the number of values in the heap.
+func (h *FibonacciHeap[T]) Len() int {
+	return h.n
+}
+
+// Unlinks n from its sibling list, leaving it in a list of its own.
+func (n *FibonacciNode[T]) unlink() {
+	n.left.right = n.right
+	n.right.left = n.left
+	n.left, n.right = n, n
+}
+
+// Links the lone node n into the list after a.
+func (a *FibonacciNode[T]) splice(n *FibonacciNode[T]) {
+	n.left = a
+	n.right = a.right
+	a.right.left = n
+	a.right = n
+}
+
+// Adds the lone node n to the root list.
+func (h *FibonacciHeap[T]) addRoot(n *FibonacciNode[T]) {
+	n.parent = nil
+	if h.min == nil {
+		h.min = n
+		return
+	}
+	h.min.splice(n)
+	if h.less(n.Value, h.min.Value) {
+		h.min = n
+	}
+}
+
+// Insert adds value to the heap and returns its node. Complexity is O(1).
+func (h *FibonacciHeap[T]) Insert(value T) *FibonacciNode[T] {
+	n := &FibonacciNode[T]{Value: value}
+	n.left, n.right = n, n
+	h.addRoot(n)
+	h.n++
+	return n
+}
+
+// Min returns the value at the top of the heap without removing it.
+func (h *FibonacciHeap[T]) Min() T {
+	return h.min.Value
+}
+
+// Links roots of equal degree until every root has a distinct degree.
+func (h *FibonacciHeap[T]) consolidate() {
+	var roots []*FibonacciNode[T]
+	for x := h.min; ; {
+		roots = append(roots, x)
+		if x = x.right; x == h.min {
+			break
+		}
+	}
+	var byDegree []*FibonacciNode[T]
+	for _, x := range roots {
+		x.left, x.right = x, x
+		d := x.degree
+		for d < len(byDegree) && byDegree[d] != nil {
+			y := byDegree[d]
+			if h.less(y.Value, x.Value) {
+				x, y = y, x
+			}
+			// make y a child of x
+			if x.child == nil {
+				x.child = y
+			} else {
+				x.child.splice(y)
+			}
+			y.parent = x
+			y.mark = false
+			x.degree++
+			byDegree[d] = nil
+			d++
+		}
+		for d >= len(byDegree) {
+			byDegree = append(byDegree, nil)
+		}
+		byDegree[d] = x
+	}
+	h.min = nil
+	for _, x := range byDegree {
+		if x != nil {
+			h.addRoot(x)
+		}
+	}
+}
+
+// ExtractMin removes and returns the value at the top of the heap.
+// Amortized complexity is O(lg n).
+func (h *FibonacciHeap[T]) ExtractMin() T {
+	z := h.min
+	for z.child != nil {
+		c := z.child
+		if c.right == c {
+			z.child = nil
+		} else {
+			z.child = c.right
+		}
+		c.unlink()
+		h.addRoot(c)
+	}
+	if z.right == z {
+		h.min = nil
+	} else {
+		h.min = z.right
+		z.unlink()
+		h.consolidate()
+	}
+	z.degree = 0
+	h.n--
+	return z.Value
+}
+
+// Moves x from the children of y to the root list.
+func (h *FibonacciHeap[T]) cut(x, y *FibonacciNode[T]) {
+	if y.child == x {
+		if x.right == x {
+			y.child = nil
+		} else {
+			y.child = x.right
+		}
+	}
+	x.unlink()
+	y.degree--
+	x.mark = false
+	h.addRoot(x)
+}
+
+func (h *FibonacciHeap[T]) cascadingCut(y *FibonacciNode[T]) {
+	for z := y.parent; z != nil; y, z = z, z.parent {
+		if !y.mark {
+			y.mark = true
+			return
+		}
+		h.cut(y, z)
+	}
+}
+
+// DecreaseKey sets n's value to value, which must not be extracted
+// later than n's old value. Amortized complexity is O(1).
+func (h *FibonacciHeap[T]) DecreaseKey(n *FibonacciNode[T], value T) {
+	n.Value = value
+	if y := n.parent; y != nil && h.less(n.Value, y.Value) {
+		h.cut(n, y)
+		h.cascadingCut(y)
+	}
+	if h.less(n.Value, h.min.Value) {
+		h.min = n
+	}
+}
+
+// Delete removes n from the heap and returns its value.
+// Amortized complexity is O(lg n).
+func (h *FibonacciHeap[T]) Delete(n *FibonacciNode[T]) T {
+	if y := n.parent; y != nil {
+		h.cut(n, y)
+		h.cascadingCut(y)
+	}
+	h.min = n // n is now a root; treat it as the minimum
+	return h.ExtractMin()
+}
+
+// Meld moves every value in other into h, leaving other empty.
+// Both heaps must use the same ordering. Complexity is O(1).
+func (h *FibonacciHeap[T]) Meld(other *FibonacciHeap[T]) {
+	if other.min != nil {
+		if h.min == nil {
+			h.min = other.min
+		} else {
+			// join the two circular root lists
+			hRight, oLeft := h.min.right, other.min.left
+			h.min.right = other.min
+			other.min.left = h.min
+			hRight.left = oLeft
+			oLeft.right = hRight
+			if h.less(other.min.Value, h.min.Value) {
+				h.min = other.min
+			}
+		}
+	}
+	h.n += other.n
+	other.min, other.n = nil, 0
+}
@@ -0,0 +1,133 @@
+package heap
+
+import (
+	"cmp"
+)
+
+// Heap is a ready to use heap of values of type T. Unlike Interface,
+// it needs no collection type of its own and can be a min or a max heap.
+//
+// The heap is ordered by less: if less(a, b), a is popped before b.
+// Pop, Peek and Replace panic if the heap is empty.
+type Heap[T any] struct {
+	s     []T
+	less  func(a, b T) bool
+	arity int
+}
+
+// New returns an empty binary heap ordered by less.
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return NewDary(2, less)
+}
+
+// NewDary returns an empty heap ordered by less where every node has
+// up to d children. A larger d makes the heap shallower, so Push is
+// cheaper and Pop compares more children per level; 4 is often faster
+// than 2 in practice. If d is less than 2, 2 is used.
+func NewDary[T any](d int, less func(a, b T) bool) *Heap[T] {
+	if d < 2 {
+		d = 2
+	}
+	return &Heap[T]{less: less, arity: d}
+}
+
+// NewMin returns an empty heap that pops its smallest value first.
+func NewMin[T cmp.Ordered]() *Heap[T] {
+	return New(cmp.Less[T])
+}
+
+// NewMax returns an empty heap that pops its largest value first.
+func NewMax[T cmp.Ordered]() *Heap[T] {
+	return New(func(a, b T) bool { return cmp.Less(b, a) })
+}
+
+// From turns s into a heap ordered by less in O(n) time.
+// The heap takes ownership of s; s must not be used afterwards.
+func From[T any](s []T, less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{s: s, less: less, arity: 2}
+	for i := len(s)/2 - 1; i >= 0; i-- { // start at first non leaf
+		h.shuffleDown(i)
+	}
+	return h
+}
+
+// Len returns the number of values in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.s)
+}
+
+func (h *Heap[T]) shuffleUp(i int) {
+	for i > 0 {
+		parent := (i - 1) / h.arity
+		if !h.less(h.s[i], h.s[parent]) {
+			break
+		}
+		h.s[i], h.s[parent] = h.s[parent], h.s[i]
+		i = parent
+	}
+}
+
+func (h *Heap[T]) shuffleDown(i int) {
+	n := len(h.s)
+	for {
+		first := h.arity*i + 1
+		if first >= n || first < 0 { // first < 0 on int overflow
+			return
+		}
+		child := first
+		for c := first + 1; c < n && c < first+h.arity; c++ {
+			if h.less(h.s[c], h.s[child]) {
+				child = c
+			}
+		}
+		if !h.less(h.s[child], h.s[i]) {
+			return
+		}
+		h.s[i], h.s[child] = h.s[child], h.s[i]
+		i = child
+	}
+}
+
+// Push adds value to the heap. Complexity is O(lg n).
+func (h *Heap[T]) Push(value T) {
+	h.s = append(h.s, value)
+	h.shuffleUp(len(h.s) - 1)
+}
+
+// Peek returns the value at the top of the heap without removing it.
+func (h *Heap[T]) Peek() T {
+	return h.s[0]
+}
+
+// Pop removes and returns the value at the top of the heap. Complexity is O(lg n).
+func (h *Heap[T]) Pop() T {
+	n := len(h.s) - 1
+	top := h.s[0]
+	h.s[0] = h.s[n]
+	var zero T
+	h.s[n] = zero // garbage collect
+	h.s = h.s[:n]
+	h.shuffleDown(0)
+	return top
+}
+
+// PushPop pushes value and then pops the top of the heap, which may be
+// value itself. It is faster than a Push followed by a Pop and works on
+// an empty heap.
+func (h *Heap[T]) PushPop(value T) T {
+	if len(h.s) == 0 || !h.less(h.s[0], value) {
+		return value
+	}
+	value, h.s[0] = h.s[0], value
+	h.shuffleDown(0)
+	return value
+}
+
+// Replace pops the top of the heap and then pushes value.
+// It is faster than a Pop followed by a Push.
+func (h *Heap[T]) Replace(value T) T {
+	top := h.s[0]
+	h.s[0] = value
+	h.shuffleDown(0)
+	return top
+}
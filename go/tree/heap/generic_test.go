@@ -0,0 +1,120 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func (h *Heap[T]) verify(t *testing.T) {
+	for i := 1; i < len(h.s); i++ {
+		if h.less(h.s[i], h.s[(i-1)/h.arity]) {
+			t.Errorf("heap invariant invalidated [%d] = %v before parent %v", i, h.s[i], h.s[(i-1)/h.arity])
+		}
+	}
+}
+
+func TestHeapMinMax(t *testing.T) {
+	ints := rand.New(rand.NewSource(32)).Perm(100)
+	min, max := NewMin[int](), NewMax[int]()
+	for _, v := range ints {
+		min.Push(v)
+		max.Push(v)
+		min.verify(t)
+		max.verify(t)
+	}
+	for i := 0; i < 100; i++ {
+		if got := min.Peek(); got != i {
+			t.Errorf("min Peek = %v; want %v", got, i)
+		}
+		if got := min.Pop(); got != i {
+			t.Errorf("min Pop = %v; want %v", got, i)
+		}
+		if got := max.Pop(); got != 99-i {
+			t.Errorf("max Pop = %v; want %v", got, 99-i)
+		}
+		min.verify(t)
+		max.verify(t)
+	}
+	if min.Len() != 0 || max.Len() != 0 {
+		t.Errorf("heaps not empty after popping everything")
+	}
+}
+
+func TestHeapFrom(t *testing.T) {
+	ints := rand.New(rand.NewSource(32)).Perm(100)
+	h := From(append([]int(nil), ints...), func(a, b int) bool { return a > b })
+	h.verify(t)
+	sort.Sort(sort.Reverse(sort.IntSlice(ints)))
+	for _, want := range ints {
+		if got := h.Pop(); got != want {
+			t.Fatalf("Pop = %v; want %v", got, want)
+		}
+	}
+}
+
+func TestHeapPushPopReplace(t *testing.T) {
+	h := NewMin[int]()
+	if got := h.PushPop(5); got != 5 || h.Len() != 0 {
+		t.Errorf("PushPop on empty heap = %v, Len %v; want 5, 0", got, h.Len())
+	}
+	for _, v := range []int{4, 8, 6} {
+		h.Push(v)
+	}
+	if got := h.PushPop(2); got != 2 {
+		t.Errorf("PushPop(2) = %v; want 2", got)
+	}
+	if got := h.PushPop(7); got != 4 {
+		t.Errorf("PushPop(7) = %v; want 4", got)
+	}
+	h.verify(t)
+	if got := h.Replace(1); got != 6 {
+		t.Errorf("Replace(1) = %v; want 6", got)
+	}
+	h.verify(t)
+	for _, want := range []int{1, 7, 8} {
+		if got := h.Pop(); got != want {
+			t.Errorf("Pop = %v; want %v", got, want)
+		}
+	}
+}
+
+func TestHeapDary(t *testing.T) {
+	r := rand.New(rand.NewSource(34))
+	for d := 2; d <= 5; d++ {
+		h := NewDary(d, func(a, b int) bool { return a < b })
+		var want []int
+		for i := 0; i < 500; i++ {
+			if r.Intn(3) == 0 && h.Len() > 0 {
+				sort.Ints(want)
+				if got := h.Pop(); got != want[0] {
+					t.Fatalf("arity %v: Pop = %v; want %v", d, got, want[0])
+				}
+				want = want[1:]
+			} else {
+				v := r.Intn(100)
+				h.Push(v)
+				want = append(want, v)
+			}
+			h.verify(t)
+		}
+	}
+}
+
+func benchmarkHeapPushPop(b *testing.B, d int) {
+	ints := rand.New(rand.NewSource(1)).Perm(1 << 16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h := NewDary(d, func(a, b int) bool { return a < b })
+		for _, v := range ints {
+			h.Push(v)
+		}
+		for h.Len() > 0 {
+			h.Pop()
+		}
+	}
+}
+
+func BenchmarkHeapBinary(b *testing.B)     { benchmarkHeapPushPop(b, 2) }
+func BenchmarkHeapQuaternary(b *testing.B) { benchmarkHeapPushPop(b, 4) }
+func BenchmarkHeapOctonary(b *testing.B)   { benchmarkHeapPushPop(b, 8) }
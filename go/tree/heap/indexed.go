@@ -0,0 +1,146 @@
+package heap
+
+// Element is a value in an Indexed heap. Push returns an Element that
+// can later be handed back to the heap to update or remove the value
+// without searching for it.
+type Element[T any] struct {
+	Value T
+	index int // index into the heap, or -1 once removed
+}
+
+// Indexed is a heap that tracks the position of every value it holds,
+// so that values can be updated or removed in O(lg n) given their Element.
+// This is the priority queue needed by Dijkstra's and Prim's algorithms.
+//
+// The heap is ordered by less: if less(a, b), a is popped before b.
+// Use < for a min heap and > for a max heap.
+// Pop and Peek panic if the heap is empty.
+type Indexed[T any] struct {
+	elems []*Element[T]
+	less  func(a, b T) bool
+	arity int
+}
+
+// NewIndexed returns an empty indexed binary heap ordered by less.
+func NewIndexed[T any](less func(a, b T) bool) *Indexed[T] {
+	return NewIndexedDary(2, less)
+}
+
+// NewIndexedDary returns an empty indexed heap ordered by less where
+// every node has up to d children. See NewDary.
+func NewIndexedDary[T any](d int, less func(a, b T) bool) *Indexed[T] {
+	if d < 2 {
+		d = 2
+	}
+	return &Indexed[T]{less: less, arity: d}
+}
+
+// Len returns the number of values in the heap.
+func (h *Indexed[T]) Len() int {
+	return len(h.elems)
+}
+
+func (h *Indexed[T]) swap(i, j int) {
+	h.elems[i], h.elems[j] = h.elems[j], h.elems[i]
+	h.elems[i].index = i
+	h.elems[j].index = j
+}
+
+func (h *Indexed[T]) shuffleUp(i int) {
+	for i > 0 {
+		parent := (i - 1) / h.arity
+		if !h.less(h.elems[i].Value, h.elems[parent].Value) {
+			break
+		}
+		h.swap(parent, i)
+		i = parent
+	}
+}
+
+// Returns whether the element at i moved.
+func (h *Indexed[T]) shuffleDown(i int) bool {
+	start := i
+	n := len(h.elems)
+	for {
+		first := h.arity*i + 1
+		if first >= n || first < 0 { // first < 0 on int overflow
+			break
+		}
+		child := first
+		for c := first + 1; c < n && c < first+h.arity; c++ {
+			if h.less(h.elems[c].Value, h.elems[child].Value) {
+				child = c
+			}
+		}
+		if !h.less(h.elems[child].Value, h.elems[i].Value) {
+			break
+		}
+		h.swap(child, i)
+		i = child
+	}
+	return i > start
+}
+
+// Push adds value to the heap and returns its Element. Complexity is O(lg n).
+func (h *Indexed[T]) Push(value T) *Element[T] {
+	e := &Element[T]{Value: value, index: len(h.elems)}
+	h.elems = append(h.elems, e)
+	h.shuffleUp(e.index)
+	return e
+}
+
+// Peek returns the value at the top of the heap without removing it.
+func (h *Indexed[T]) Peek() T {
+	return h.elems[0].Value
+}
+
+// Pop removes and returns the value at the top of the heap. Complexity is O(lg n).
+func (h *Indexed[T]) Pop() T {
+	return h.Remove(h.elems[0])
+}
+
+// Contains returns whether e is still in the heap.
+func (h *Indexed[T]) Contains(e *Element[T]) bool {
+	return e.index >= 0 && e.index < len(h.elems) && h.elems[e.index] == e
+}
+
+// Remove removes e from the heap and returns its value. Complexity is O(lg n).
+// e must be in the heap.
+func (h *Indexed[T]) Remove(e *Element[T]) T {
+	i := e.index
+	n := len(h.elems) - 1
+	if i != n {
+		h.swap(i, n)
+	}
+	h.elems[n] = nil // garbage collect
+	h.elems = h.elems[:n]
+	if i != n {
+		h.Fix(h.elems[i])
+	}
+	e.index = -1
+	return e.Value
+}
+
+// Fix restores the heap ordering after e.Value has been changed in place.
+// Complexity is O(lg n). e must be in the heap.
+func (h *Indexed[T]) Fix(e *Element[T]) {
+	if !h.shuffleDown(e.index) {
+		h.shuffleUp(e.index)
+	}
+}
+
+// Update sets e's value to value and restores the heap ordering.
+// Complexity is O(lg n). e must be in the heap.
+func (h *Indexed[T]) Update(e *Element[T], value T) {
+	e.Value = value
+	h.Fix(e)
+}
+
+// DecreaseKey sets e's value to value, which must not be popped
+// later than e's old value, and moves e toward the top of the heap.
+// This is cheaper than Update when the direction of change is known.
+// Complexity is O(lg n). e must be in the heap.
+func (h *Indexed[T]) DecreaseKey(e *Element[T], value T) {
+	e.Value = value
+	h.shuffleUp(e.index)
+}
@@ -0,0 +1,67 @@
+package heap
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func (h *Indexed[T]) verify(t *testing.T) {
+	for i, e := range h.elems {
+		if e.index != i {
+			t.Errorf("element %v has index %v; it is at %v", e.Value, e.index, i)
+		}
+		if i > 0 && h.less(e.Value, h.elems[(i-1)/h.arity].Value) {
+			t.Errorf("heap invariant invalidated [%d] = %v before parent %v", i, e.Value, h.elems[(i-1)/h.arity].Value)
+		}
+	}
+}
+
+func TestIndexed(t *testing.T) {
+	for d := 2; d <= 4; d++ {
+		testIndexed(t, NewIndexedDary(d, func(a, b int) bool { return a < b }))
+	}
+}
+
+func testIndexed(t *testing.T, h *Indexed[int]) {
+	r := rand.New(rand.NewSource(31))
+	elems := make([]*Element[int], 0)
+	for i := 0; i < 100; i++ {
+		elems = append(elems, h.Push(r.Intn(1000)))
+		h.verify(t)
+	}
+	for i := 0; i < len(elems); i += 3 {
+		h.Update(elems[i], r.Intn(1000))
+		h.verify(t)
+	}
+	for i := 1; i < len(elems); i += 3 {
+		h.DecreaseKey(elems[i], elems[i].Value-r.Intn(100))
+		h.verify(t)
+	}
+	for i := 2; i < len(elems); i += 5 {
+		v := elems[i].Value
+		if got := h.Remove(elems[i]); got != v {
+			t.Errorf("Remove = %v; want %v", got, v)
+		}
+		if h.Contains(elems[i]) {
+			t.Errorf("heap contains removed element %v", v)
+		}
+		h.verify(t)
+	}
+	if h.Len() != 80 {
+		t.Errorf("Len = %v; want 80", h.Len())
+	}
+	last := h.Peek()
+	for h.Len() > 0 {
+		top := h.Peek()
+		if got := h.Pop(); got != top || got < last {
+			t.Errorf("Pop = %v after %v, Peek was %v", got, last, top)
+		}
+		last = top
+		h.verify(t)
+	}
+	for _, e := range elems {
+		if h.Contains(e) {
+			t.Errorf("empty heap contains element %v", e.Value)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// mergeable is the API shared by the pairing, binomial and Fibonacci heaps.
+type mergeable[N any, H any] interface {
+	Len() int
+	Insert(value int) N
+	Min() int
+	ExtractMin() int
+	DecreaseKey(n N, value int)
+	Delete(n N) int
+	Meld(other H)
+}
+
+// testMergeable runs random operations on heaps from newHeap,
+// checking them against a plain slice of the live values.
+func testMergeable[N any, H mergeable[N, H]](t *testing.T, newHeap func() H, value func(N) int) {
+	r := rand.New(rand.NewSource(33))
+	h := newHeap()
+	var nodes []N
+	// the low bits of every value are unique so that extracted
+	// values can be matched back to their nodes
+	id := 0
+	random := func() int {
+		id++
+		return r.Intn(1000)<<16 | id
+	}
+	check := func() {
+		if h.Len() != len(nodes) {
+			t.Fatalf("Len = %v; want %v", h.Len(), len(nodes))
+		}
+		if len(nodes) == 0 {
+			return
+		}
+		min := value(nodes[0])
+		for _, n := range nodes {
+			if value(n) < min {
+				min = value(n)
+			}
+		}
+		if got := h.Min(); got != min {
+			t.Fatalf("Min = %v; want %v", got, min)
+		}
+	}
+	for op := 0; op < 3000; op++ {
+		switch k := r.Intn(10); {
+		case k < 4 || len(nodes) == 0:
+			nodes = append(nodes, h.Insert(random()))
+		case k < 6:
+			i := r.Intn(len(nodes))
+			h.DecreaseKey(nodes[i], value(nodes[i])-r.Intn(50)<<16)
+		case k < 7:
+			i := r.Intn(len(nodes))
+			want := value(nodes[i])
+			if got := h.Delete(nodes[i]); got != want {
+				t.Fatalf("Delete = %v; want %v", got, want)
+			}
+			nodes = append(nodes[:i], nodes[i+1:]...)
+		case k < 9:
+			min := h.Min()
+			if got := h.ExtractMin(); got != min {
+				t.Fatalf("ExtractMin = %v; Min was %v", got, min)
+			}
+			for i := range nodes {
+				if value(nodes[i]) == min {
+					nodes = append(nodes[:i], nodes[i+1:]...)
+					break
+				}
+			}
+		default:
+			other := newHeap()
+			for i := r.Intn(20); i > 0; i-- {
+				nodes = append(nodes, other.Insert(random()))
+			}
+			h.Meld(other)
+			if other.Len() != 0 {
+				t.Fatalf("melded heap still has %v values", other.Len())
+			}
+		}
+		check()
+	}
+	want := make([]int, len(nodes))
+	for i := range nodes {
+		want[i] = value(nodes[i])
+	}
+	sort.Ints(want)
+	for _, w := range want {
+		if got := h.ExtractMin(); got != w {
+			t.Fatalf("ExtractMin = %v; want %v", got, w)
+		}
+	}
+}
+
+func intLess(a, b int) bool { return a < b }
+
+func TestPairingHeap(t *testing.T) {
+	testMergeable(t, func() *PairingHeap[int] { return NewPairing(intLess) },
+		func(n *PairingNode[int]) int { return n.Value })
+}
+
+func TestBinomialHeap(t *testing.T) {
+	testMergeable(t, func() *BinomialHeap[int] { return NewBinomial(intLess) },
+		func(n *BinomialNode[int]) int { return n.Value })
+}
+
+func TestFibonacciHeap(t *testing.T) {
+	testMergeable(t, func() *FibonacciHeap[int] { return NewFibonacci(intLess) },
+		func(n *FibonacciNode[int]) int { return n.Value })
+}
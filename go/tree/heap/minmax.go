@@ -0,0 +1,217 @@
+package heap
+
+import (
+	"math/bits"
+)
+
+// MinMax is a double ended priority queue: both its smallest and its
+// largest value can be looked at in O(1) and removed in O(lg n).
+//
+// It is a min-max heap. Nodes on even levels (the root is level 0) are
+// no greater than anything below them and nodes on odd levels are no
+// less than anything below them, so the minimum is the root and the
+// maximum is one of the root's children.
+//
+// Values are ordered by less. PeekMin, PeekMax, PopMin and PopMax panic
+// if the heap is empty.
+type MinMax[T any] struct {
+	s    []T
+	less func(a, b T) bool
+}
+
+// NewMinMax returns an empty min-max heap ordered by less.
+func NewMinMax[T any](less func(a, b T) bool) *MinMax[T] {
+	return &MinMax[T]{less: less}
+}
+
+// Len returns the number of values in the heap.
+func (h *MinMax[T]) Len() int {
+	return len(h.s)
+}
+
+// Returns whether i is on a max level.
+func isMaxLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 0
+}
+
+// Returns whether a belongs above b on a level of the given kind.
+func (h *MinMax[T]) above(a, b T, max bool) bool {
+	if max {
+		return h.less(b, a)
+	}
+	return h.less(a, b)
+}
+
+func (h *MinMax[T]) swap(i, j int) {
+	h.s[i], h.s[j] = h.s[j], h.s[i]
+}
+
+func (h *MinMax[T]) shuffleUp(i int) {
+	if i == 0 {
+		return
+	}
+	max := isMaxLevel(i)
+	parent := (i - 1) / 2
+	if h.above(h.s[parent], h.s[i], max) {
+		// i belongs on the other kind of level
+		h.swap(i, parent)
+		i = parent
+		max = !max
+	}
+	for i > 2 { // has a grandparent
+		grand := ((i-1)/2 - 1) / 2
+		if !h.above(h.s[i], h.s[grand], max) {
+			return
+		}
+		h.swap(i, grand)
+		i = grand
+	}
+}
+
+func (h *MinMax[T]) shuffleDown(i int) {
+	max := isMaxLevel(i)
+	n := len(h.s)
+	for {
+		first := 2*i + 1
+		if first >= n {
+			return
+		}
+		// find the best of i's children and grandchildren
+		m := first
+		candidates := [...]int{first + 1, 2*first + 1, 2*first + 2, 2*first + 3, 2*first + 4}
+		for _, c := range candidates {
+			if c < n && h.above(h.s[c], h.s[m], max) {
+				m = c
+			}
+		}
+		if !h.above(h.s[m], h.s[i], max) {
+			return
+		}
+		h.swap(m, i)
+		if m <= first+1 { // a child; it cannot have anything below it out of place
+			return
+		}
+		if parent := (m - 1) / 2; h.above(h.s[parent], h.s[m], max) {
+			h.swap(m, parent)
+		}
+		i = m
+	}
+}
+
+// Push adds value to the heap. Complexity is O(lg n).
+func (h *MinMax[T]) Push(value T) {
+	h.s = append(h.s, value)
+	h.shuffleUp(len(h.s) - 1)
+}
+
+// Returns the index of the maximum value.
+func (h *MinMax[T]) maxIndex() int {
+	switch len(h.s) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.less(h.s[1], h.s[2]) {
+		return 2
+	}
+	return 1
+}
+
+// PeekMin returns the smallest value without removing it.
+func (h *MinMax[T]) PeekMin() T {
+	return h.s[0]
+}
+
+// PeekMax returns the largest value without removing it.
+func (h *MinMax[T]) PeekMax() T {
+	return h.s[h.maxIndex()]
+}
+
+func (h *MinMax[T]) remove(i int) T {
+	n := len(h.s) - 1
+	removed := h.s[i]
+	h.s[i] = h.s[n]
+	var zero T
+	h.s[n] = zero // garbage collect
+	h.s = h.s[:n]
+	if i < n {
+		h.shuffleDown(i)
+	}
+	return removed
+}
+
+// PopMin removes and returns the smallest value. Complexity is O(lg n).
+func (h *MinMax[T]) PopMin() T {
+	return h.remove(0)
+}
+
+// PopMax removes and returns the largest value. Complexity is O(lg n).
+func (h *MinMax[T]) PopMax() T {
+	return h.remove(h.maxIndex())
+}
+
+// TopK keeps the k largest values pushed to it, as ordered by less.
+// Once full, every Push evicts the smallest value kept, so a stream of
+// n values is reduced in O(n lg k) time and O(k) space.
+type TopK[T any] struct {
+	h *MinMax[T]
+	k int
+}
+
+// NewTopK returns an empty TopK that keeps the k largest values ordered by less.
+func NewTopK[T any](k int, less func(a, b T) bool) *TopK[T] {
+	return &TopK[T]{h: NewMinMax(less), k: k}
+}
+
+// Len returns the number of values kept, which is at most k.
+func (t *TopK[T]) Len() int {
+	return t.h.Len()
+}
+
+// Push offers value to t. If t is full, the smallest of the kept values
+// and value is evicted and returned with ok true.
+func (t *TopK[T]) Push(value T) (evicted T, ok bool) {
+	if t.k <= 0 {
+		return value, true
+	}
+	if t.h.Len() < t.k {
+		t.h.Push(value)
+		return evicted, false
+	}
+	if !t.h.less(t.h.PeekMin(), value) {
+		return value, true
+	}
+	evicted = t.h.s[0]
+	t.h.s[0] = value
+	t.h.shuffleDown(0)
+	return evicted, true
+}
+
+// Min returns the smallest value kept, the one the next Push would evict.
+// ok is false if no values are kept.
+func (t *TopK[T]) Min() (min T, ok bool) {
+	if t.h.Len() == 0 {
+		return min, false
+	}
+	return t.h.PeekMin(), true
+}
+
+// Max returns the largest value kept. ok is false if no values are kept.
+func (t *TopK[T]) Max() (max T, ok bool) {
+	if t.h.Len() == 0 {
+		return max, false
+	}
+	return t.h.PeekMax(), true
+}
+
+// Values returns the kept values from largest to smallest.
+// Complexity is O(k lg k).
+func (t *TopK[T]) Values() []T {
+	c := &MinMax[T]{s: append([]T(nil), t.h.s...), less: t.h.less}
+	values := make([]T, 0, c.Len())
+	for c.Len() > 0 {
+		values = append(values, c.PopMax())
+	}
+	return values
+}
@@ -0,0 +1,98 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func (h *MinMax[T]) verify(t *testing.T) {
+	for i := 1; i < len(h.s); i++ {
+		// every ancestor must be on the right side of i for its level
+		for a := (i - 1) / 2; ; a = (a - 1) / 2 {
+			if h.above(h.s[i], h.s[a], isMaxLevel(a)) {
+				t.Fatalf("min-max invariant invalidated: [%d] = %v out of order with ancestor [%d] = %v", i, h.s[i], a, h.s[a])
+			}
+			if a == 0 {
+				break
+			}
+		}
+	}
+}
+
+func TestMinMaxAgainstModel(t *testing.T) {
+	r := rand.New(rand.NewSource(36))
+	h := NewMinMax(func(a, b int) bool { return a < b })
+	var model []int
+	for i := 0; i < 5000; i++ {
+		switch op := r.Intn(4); {
+		case op < 2 || len(model) == 0:
+			v := r.Intn(200)
+			h.Push(v)
+			model = append(model, v)
+			sort.Ints(model)
+		case op == 2:
+			if got := h.PeekMin(); got != model[0] {
+				t.Fatalf("PeekMin = %v; want %v", got, model[0])
+			}
+			if got := h.PopMin(); got != model[0] {
+				t.Fatalf("PopMin = %v; want %v", got, model[0])
+			}
+			model = model[1:]
+		default:
+			want := model[len(model)-1]
+			if got := h.PeekMax(); got != want {
+				t.Fatalf("PeekMax = %v; want %v", got, want)
+			}
+			if got := h.PopMax(); got != want {
+				t.Fatalf("PopMax = %v; want %v", got, want)
+			}
+			model = model[:len(model)-1]
+		}
+		if h.Len() != len(model) {
+			t.Fatalf("Len = %v; want %v", h.Len(), len(model))
+		}
+		h.verify(t)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	r := rand.New(rand.NewSource(36))
+	for _, k := range []int{0, 1, 5, 100} {
+		top := NewTopK(k, func(a, b int) bool { return a < b })
+		if _, ok := top.Min(); ok {
+			t.Errorf("k=%v: Min on empty TopK returned ok", k)
+		}
+		var all []int
+		evictions := 0
+		for i := 0; i < 1000; i++ {
+			v := r.Intn(500)
+			all = append(all, v)
+			if evicted, ok := top.Push(v); ok {
+				evictions++
+				if min, ok := top.Min(); ok && evicted > min {
+					t.Errorf("k=%v: evicted %v larger than kept %v", k, evicted, min)
+				}
+			}
+		}
+		want := min(k, len(all))
+		if top.Len() != want || evictions != len(all)-want {
+			t.Errorf("k=%v: Len = %v after %v evictions; want %v", k, top.Len(), evictions, want)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(all)))
+		got := top.Values()
+		for i := range got {
+			if got[i] != all[i] {
+				t.Fatalf("k=%v: Values = %v; want %v", k, got, all[:want])
+			}
+		}
+		if k > 0 {
+			if max, _ := top.Max(); max != all[0] {
+				t.Errorf("k=%v: Max = %v; want %v", k, max, all[0])
+			}
+			if min, _ := top.Min(); min != all[want-1] {
+				t.Errorf("k=%v: Min = %v; want %v", k, min, all[want-1])
+			}
+		}
+	}
+}
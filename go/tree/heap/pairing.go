@@ -0,0 +1,143 @@
+package heap
+
+// PairingHeap is a mergeable heap represented as a single multiway tree.
+// It is simple and very fast in practice: Insert, Meld and Min are O(1),
+// ExtractMin and Delete are amortized O(lg n) and DecreaseKey is amortized
+// o(lg n).
+//
+// The heap is ordered by less: if less(a, b), a is extracted before b.
+// Min and ExtractMin panic if the heap is empty.
+type PairingHeap[T any] struct {
+	root *PairingNode[T]
+	n    int
+	less func(a, b T) bool
+}
+
+// PairingNode holds a value in a PairingHeap. It is returned by Insert and
+// is used as a handle for DecreaseKey and Delete. Value must only be
+// changed through DecreaseKey.
+type PairingNode[T any] struct {
+	Value T
+	child *PairingNode[T] // leftmost child
+	next  *PairingNode[T] // right sibling
+	prev  *PairingNode[T] // left sibling, or parent if leftmost
+}
+
+// NewPairing returns an empty pairing heap ordered by less.
+func NewPairing[T any](less func(a, b T) bool) *PairingHeap[T] {
+	return &PairingHeap[T]{less: less}
+}
+
+// Len returns the number of values in the heap.
+func (h *PairingHeap[T]) Len() int {
+	return h.n
+}
+
+// Makes the root with the larger value the leftmost child of the other.
+func (h *PairingHeap[T]) meld(a, b *PairingNode[T]) *PairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.less(b.Value, a.Value) {
+		a, b = b, a
+	}
+	b.prev = a
+	b.next = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// Melds a list of siblings into one tree: first pairwise from
+// left to right, then the pairs from right to left.
+func (h *PairingHeap[T]) mergePairs(first *PairingNode[T]) *PairingNode[T] {
+	var pairs []*PairingNode[T]
+	for first != nil {
+		a, b := first, first.next
+		first = nil
+		if b != nil {
+			first = b.next
+			b.prev, b.next = nil, nil
+		}
+		a.prev, a.next = nil, nil
+		pairs = append(pairs, h.meld(a, b))
+	}
+	var root *PairingNode[T]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.meld(pairs[i], root)
+	}
+	return root
+}
+
+// Cuts the subtree at n out of its parent.
+func (h *PairingHeap[T]) detach(n *PairingNode[T]) {
+	if n.prev.child == n {
+		n.prev.child = n.next
+	} else {
+		n.prev.next = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	}
+	n.prev, n.next = nil, nil
+}
+
+// Insert adds value to the heap and returns its node. Complexity is O(1).
+func (h *PairingHeap[T]) Insert(value T) *PairingNode[T] {
+	n := &PairingNode[T]{Value: value}
+	h.root = h.meld(h.root, n)
+	h.n++
+	return n
+}
+
+// Min returns the value at the top of the heap without removing it.
+func (h *PairingHeap[T]) Min() T {
+	return h.root.Value
+}
+
+// ExtractMin removes and returns the value at the top of the heap.
+// Amortized complexity is O(lg n).
+func (h *PairingHeap[T]) ExtractMin() T {
+	min := h.root
+	h.root = h.mergePairs(min.child)
+	min.child = nil
+	h.n--
+	return min.Value
+}
+
+// DecreaseKey sets n's value to value, which must not be extracted
+// later than n's old value.
+func (h *PairingHeap[T]) DecreaseKey(n *PairingNode[T], value T) {
+	n.Value = value
+	if n == h.root {
+		return
+	}
+	h.detach(n)
+	h.root = h.meld(h.root, n)
+}
+
+// Delete removes n from the heap and returns its value.
+// Amortized complexity is O(lg n).
+func (h *PairingHeap[T]) Delete(n *PairingNode[T]) T {
+	if n == h.root {
+		return h.ExtractMin()
+	}
+	h.detach(n)
+	h.root = h.meld(h.root, h.mergePairs(n.child))
+	n.child = nil
+	h.n--
+	return n.Value
+}
+
+// Meld moves every value in other into h, leaving other empty.
+// Both heaps must use the same ordering. Complexity is O(1).
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	h.root = h.meld(h.root, other.root)
+	h.n += other.n
+	other.root, other.n = nil, 0
+}
@@ -0,0 +1,202 @@
+package heap
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned by Queue operations that cannot complete
+// because the queue has been closed.
+var ErrClosed = errors.New("heap: queue closed")
+
+// Queue is a priority queue that is safe for concurrent use by many
+// producers and consumers. Pop blocks until a value is available and,
+// if the queue has a capacity, Push blocks until there is room.
+//
+// The queue is ordered by less: if less(a, b), a is popped before b.
+type Queue[T any] struct {
+	mu       sync.Mutex
+	h        *Indexed[T]
+	capacity int
+	closed   bool
+	// notEmpty is closed when a value is added, waking the goroutines
+	// blocked in Pop, and notFull when one leaves, waking those blocked
+	// in Push. Each is made only once a goroutine needs to wait on it.
+	notEmpty chan struct{}
+	notFull  chan struct{}
+}
+
+// QueueItem is a value in a Queue. Push returns one so that the value
+// can later be updated or removed without searching for it. Unlike an
+// Element, its value can only be read through Value, which takes the
+// queue's lock, so it is safe to use from any goroutine.
+type QueueItem[T any] struct {
+	q *Queue[T]
+	e *Element[T]
+}
+
+// Value returns the item's current value, whether or not it is still in the queue.
+func (it *QueueItem[T]) Value() T {
+	it.q.mu.Lock()
+	defer it.q.mu.Unlock()
+	return it.e.Value
+}
+
+// NewQueue returns an empty queue ordered by less that holds at most
+// capacity values. If capacity is less than 1, the queue is unbounded.
+func NewQueue[T any](capacity int, less func(a, b T) bool) *Queue[T] {
+	return &Queue[T]{
+		h:        NewIndexed(less),
+		capacity: capacity,
+	}
+}
+
+// Returns *c, making it first if no one is waiting on it yet.
+// Must be called with q.mu held.
+func wait(c *chan struct{}) chan struct{} {
+	if *c == nil {
+		*c = make(chan struct{})
+	}
+	return *c
+}
+
+// Wakes every goroutine waiting on *c. Must be called with q.mu held.
+func wake(c *chan struct{}) {
+	if *c != nil {
+		close(*c)
+		*c = nil
+	}
+}
+
+func (q *Queue[T]) full() bool {
+	return q.capacity > 0 && q.h.Len() >= q.capacity
+}
+
+// Len returns the number of values in the queue.
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.h.Len()
+}
+
+// Push adds value to the queue, waiting for room if the queue is full.
+// The returned QueueItem can be used with Update and Remove.
+// Push returns ErrClosed if the queue is closed, or ctx.Err()
+// if ctx is done before there is room.
+func (q *Queue[T]) Push(ctx context.Context, value T) (*QueueItem[T], error) {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return nil, ErrClosed
+		}
+		if !q.full() {
+			e := q.h.Push(value)
+			wake(&q.notEmpty)
+			q.mu.Unlock()
+			return &QueueItem[T]{q, e}, nil
+		}
+		notFull := wait(&q.notFull)
+		q.mu.Unlock()
+		select {
+		case <-notFull:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// TryPush adds value to the queue if there is room and returns its QueueItem.
+// ok is false if the queue is full or closed.
+func (q *Queue[T]) TryPush(value T) (it *QueueItem[T], ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed || q.full() {
+		return nil, false
+	}
+	e := q.h.Push(value)
+	wake(&q.notEmpty)
+	return &QueueItem[T]{q, e}, true
+}
+
+// Pop removes and returns the value at the top of the queue, waiting for
+// one if the queue is empty. Values pushed before Close can still be
+// popped after it; once a closed queue is empty, Pop returns ErrClosed.
+// Pop returns ctx.Err() if ctx is done before a value is available.
+func (q *Queue[T]) Pop(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		if q.h.Len() > 0 {
+			v := q.h.Pop()
+			wake(&q.notFull)
+			q.mu.Unlock()
+			return v, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			var zero T
+			return zero, ErrClosed
+		}
+		notEmpty := wait(&q.notEmpty)
+		q.mu.Unlock()
+		select {
+		case <-notEmpty:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// TryPop removes and returns the value at the top of the queue
+// without waiting. ok is false if the queue is empty.
+func (q *Queue[T]) TryPop() (v T, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.h.Len() == 0 {
+		return v, false
+	}
+	v = q.h.Pop()
+	wake(&q.notFull)
+	return v, true
+}
+
+// Update changes the priority of it to value.
+// ok is false if it has already left the queue.
+func (q *Queue[T]) Update(it *QueueItem[T], value T) (ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if it.q != q || !q.h.Contains(it.e) {
+		return false
+	}
+	q.h.Update(it.e, value)
+	return true
+}
+
+// Remove removes it from the queue and returns its value.
+// ok is false if it has already left the queue.
+func (q *Queue[T]) Remove(it *QueueItem[T]) (v T, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if it.q != q || !q.h.Contains(it.e) {
+		return v, false
+	}
+	v = q.h.Remove(it.e)
+	wake(&q.notFull)
+	return v, true
+}
+
+// Close stops the queue from accepting new values and wakes every
+// goroutine blocked in Push or Pop. Blocked Pushes return ErrClosed;
+// blocked Pops return ErrClosed too, since a Pop only blocks on an empty
+// queue. Closing a closed queue does nothing.
+func (q *Queue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		wake(&q.notEmpty)
+		wake(&q.notFull)
+	}
+}
@@ -0,0 +1,187 @@
+package heap
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestQueueOrder(t *testing.T) {
+	q := NewQueue(0, func(a, b int) bool { return a < b })
+	ctx := context.Background()
+	elems := make([]*QueueItem[int], 0)
+	for _, v := range []int{5, 3, 8, 1, 9} {
+		e, err := q.Push(ctx, v)
+		if err != nil {
+			t.Fatalf("Push(%v): %v", v, err)
+		}
+		elems = append(elems, e)
+	}
+	if !q.Update(elems[2], 0) { // 8 -> 0
+		t.Errorf("Update of queued element failed")
+	}
+	if v := elems[2].Value(); v != 0 {
+		t.Errorf("Value of updated item = %v; want 0", v)
+	}
+	if v, ok := q.Remove(elems[1]); !ok || v != 3 {
+		t.Errorf("Remove = %v, %v; want 3, true", v, ok)
+	}
+	for _, want := range []int{0, 1, 5, 9} {
+		if got, err := q.Pop(ctx); err != nil || got != want {
+			t.Errorf("Pop = %v, %v; want %v", got, err, want)
+		}
+	}
+	if _, ok := q.TryPop(); ok {
+		t.Errorf("TryPop on empty queue returned ok")
+	}
+	if q.Update(elems[0], 1) {
+		t.Errorf("Update of popped element returned ok")
+	}
+	if _, ok := q.Remove(elems[0]); ok {
+		t.Errorf("Remove of popped element returned ok")
+	}
+	other := NewQueue(0, func(a, b int) bool { return a < b })
+	it, _ := other.TryPush(7)
+	if q.Update(it, 1) || q.Len() != 0 {
+		t.Errorf("Update of another queue's item returned ok")
+	}
+}
+
+func TestQueueBlocking(t *testing.T) {
+	q := NewQueue(1, func(a, b int) bool { return a < b })
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Pop(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Pop on empty queue = %v; want %v", err, context.DeadlineExceeded)
+	}
+	if _, ok := q.TryPush(1); !ok {
+		t.Fatalf("TryPush into empty queue failed")
+	}
+	if _, ok := q.TryPush(2); ok {
+		t.Errorf("TryPush into full queue returned ok")
+	}
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel2()
+	if _, err := q.Push(ctx2, 2); err != context.DeadlineExceeded {
+		t.Errorf("Push into full queue = %v; want %v", err, context.DeadlineExceeded)
+	}
+
+	pushed := make(chan error)
+	go func() {
+		_, err := q.Push(context.Background(), 3)
+		pushed <- err
+	}()
+	if v, err := q.Pop(context.Background()); err != nil || v != 1 {
+		t.Errorf("Pop = %v, %v; want 1", v, err)
+	}
+	if err := <-pushed; err != nil {
+		t.Errorf("blocked Push = %v after Pop made room", err)
+	}
+}
+
+// Returns whether c has been closed.
+func woken(c chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestQueueWakesOneSide(t *testing.T) {
+	q := NewQueue(1, func(a, b int) bool { return a < b })
+	notEmpty, notFull := wait(&q.notEmpty), wait(&q.notFull)
+	q.TryPush(1)
+	if !woken(notEmpty) || woken(notFull) {
+		t.Errorf("Push woke Pop waiters %v, Push waiters %v; want true, false", woken(notEmpty), woken(notFull))
+	}
+	notEmpty = wait(&q.notEmpty)
+	q.TryPop()
+	if woken(notEmpty) || !woken(notFull) {
+		t.Errorf("Pop woke Pop waiters %v, Push waiters %v; want false, true", woken(notEmpty), woken(notFull))
+	}
+}
+
+func TestQueueClose(t *testing.T) {
+	q := NewQueue(1, func(a, b int) bool { return a < b })
+	q.TryPush(1)
+	pushed := make(chan error)
+	go func() {
+		_, err := q.Push(context.Background(), 2)
+		pushed <- err
+	}()
+	q.Close()
+	q.Close()
+	if err := <-pushed; err != ErrClosed {
+		t.Errorf("blocked Push after Close = %v; want ErrClosed", err)
+	}
+	if _, ok := q.TryPush(3); ok {
+		t.Errorf("TryPush after Close returned ok")
+	}
+	if v, err := q.Pop(context.Background()); err != nil || v != 1 {
+		t.Errorf("Pop of value pushed before Close = %v, %v; want 1", v, err)
+	}
+	if _, err := q.Pop(context.Background()); err != ErrClosed {
+		t.Errorf("Pop of empty closed queue = %v; want ErrClosed", err)
+	}
+}
+
+// Run with -race.
+func TestQueueStress(t *testing.T) {
+	const producers, consumers, perProducer = 8, 8, 2000
+	q := NewQueue(16, func(a, b int) bool { return a < b })
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				e, err := q.Push(ctx, p*perProducer+i)
+				if err != nil {
+					t.Errorf("Push: %v", err)
+					return
+				}
+				if i%10 == 0 {
+					q.Update(e, -1-p*perProducer-i)
+					e.Value() // races with Update and Pop unless it locks
+				}
+			}
+		}(p)
+	}
+	seen := make([][]bool, consumers)
+	var consumed sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		seen[c] = make([]bool, producers*perProducer)
+		consumed.Add(1)
+		go func(c int) {
+			defer consumed.Done()
+			for {
+				v, err := q.Pop(ctx)
+				if err == ErrClosed {
+					return
+				}
+				if v < 0 {
+					v = -1 - v
+				}
+				seen[c][v] = true
+			}
+		}(c)
+	}
+	wg.Wait()
+	q.Close()
+	consumed.Wait()
+	for v := 0; v < producers*perProducer; v++ {
+		count := 0
+		for c := range seen {
+			if seen[c][v] {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Errorf("value %v popped %v times", v, count)
+		}
+	}
+}
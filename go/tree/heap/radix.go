@@ -0,0 +1,79 @@
+package heap
+
+import (
+	"math/bits"
+)
+
+// RadixHeap is a monotone min priority queue with unsigned integer keys:
+// a pushed key may never be smaller than the last key popped. That is
+// exactly the access pattern of Dijkstra's algorithm with nonnegative
+// integer weights, and it lets the heap sort values into buckets by the
+// highest bit in which their key differs from the last popped key instead
+// of comparing them. Push is O(1) and Pop is amortized O(lg C) for keys
+// no larger than C.
+//
+// Pop panics if the heap is empty.
+type RadixHeap[T any] struct {
+	// bucket i holds keys whose highest bit differing from last is bit i-1;
+	// bucket 0 holds keys equal to last
+	buckets [65][]radixItem[T]
+	last    uint64
+	n       int
+}
+
+type radixItem[T any] struct {
+	key   uint64
+	value T
+}
+
+// NewRadix returns an empty radix heap.
+func NewRadix[T any]() *RadixHeap[T] {
+	return &RadixHeap[T]{}
+}
+
+// Len returns the number of values in the heap.
+func (h *RadixHeap[T]) Len() int {
+	return h.n
+}
+
+// Push adds value with the given key to the heap. Complexity is O(1).
+// Push panics if key is smaller than the last key popped.
+func (h *RadixHeap[T]) Push(key uint64, value T) {
+	if key < h.last {
+		panic("heap: radix heap key smaller than last popped key")
+	}
+	b := bits.Len64(key ^ h.last)
+	h.buckets[b] = append(h.buckets[b], radixItem[T]{key, value})
+	h.n++
+}
+
+// Pop removes and returns a value with the smallest key, along with the key.
+func (h *RadixHeap[T]) Pop() (uint64, T) {
+	if len(h.buckets[0]) == 0 {
+		b := 1
+		for len(h.buckets[b]) == 0 {
+			b++
+		}
+		// every item in the first nonempty bucket moves to a lower
+		// bucket once last becomes the smallest key among them
+		items := h.buckets[b]
+		h.last = items[0].key
+		for _, item := range items[1:] {
+			if item.key < h.last {
+				h.last = item.key
+			}
+		}
+		for _, item := range items {
+			d := bits.Len64(item.key ^ h.last)
+			h.buckets[d] = append(h.buckets[d], item)
+		}
+		clear(items) // garbage collect
+		h.buckets[b] = items[:0]
+	}
+	n := len(h.buckets[0]) - 1
+	item := h.buckets[0][n]
+	h.buckets[0][n] = radixItem[T]{}
+	h.buckets[0] = h.buckets[0][:n]
+	h.n--
+	return item.key, item.value
+}
@@ -0,0 +1,47 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestRadixHeap(t *testing.T) {
+	r := rand.New(rand.NewSource(34))
+	h := NewRadix[int]()
+	var want []uint64
+	last := uint64(0)
+	for i := 0; i < 5000; i++ {
+		if r.Intn(3) == 0 && h.Len() > 0 {
+			sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+			key, value := h.Pop()
+			if key != want[0] || uint64(value) != key {
+				t.Fatalf("Pop = %v, %v; want %v", key, value, want[0])
+			}
+			last = key
+			want = want[1:]
+		} else {
+			key := last + uint64(r.Intn(1000))
+			if r.Intn(10) == 0 {
+				key = last // equal keys
+			}
+			h.Push(key, int(key))
+			want = append(want, key)
+		}
+		if h.Len() != len(want) {
+			t.Fatalf("Len = %v; want %v", h.Len(), len(want))
+		}
+	}
+}
+
+func TestRadixHeapNotMonotone(t *testing.T) {
+	h := NewRadix[int]()
+	h.Push(5, 0)
+	h.Pop()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Push of a key below the last popped key did not panic")
+		}
+	}()
+	h.Push(4, 0)
+}
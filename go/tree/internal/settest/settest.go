@@ -0,0 +1,128 @@
+// Package settest checks binary.OrderedSet implementations against a
+// sorted slice that holds the same values.
+package settest
+
+import (
+	"github.com/twmb/algoimpl/go/tree/binary"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// Int is an int that implements binary.Comparable.
+type Int int
+
+func (i Int) CompareTo(other binary.Comparable) int {
+	o := other.(Int)
+	if i < o {
+		return -1
+	} else if i == o {
+		return 0
+	}
+	return 1
+}
+
+// Model describes a run of random operations on an OrderedSet.
+type Model struct {
+	// Seed seeds the random operations.
+	Seed int64
+	// Ops is the number of random inserts and deletes.
+	Ops int
+	// Values are drawn from [0, Values). Fewer values mean more duplicates.
+	Values int
+	// Verify, if not nil, checks the set's internal invariants. It is
+	// called every 100 operations and after the set is emptied.
+	Verify func(t *testing.T)
+}
+
+// Returns the values of walked, which must all be Ints.
+func ints(walked []binary.Comparable) []int {
+	r := make([]int, len(walked))
+	for i := range walked {
+		r[i] = int(walked[i].(Int))
+	}
+	return r
+}
+
+func (m Model) verify(t *testing.T) {
+	if m.Verify != nil {
+		m.Verify(t)
+	}
+}
+
+// Run runs m's operations on s, which must be empty, and checks after
+// every one that Len, Contains and Successor agree with the model. It
+// then checks Walk, Minimum and Maximum, and empties s in random order.
+func (m Model) Run(t *testing.T, s binary.OrderedSet) {
+	t.Helper()
+	r := rand.New(rand.NewSource(m.Seed))
+	var model []int
+	for op := 0; op < m.Ops; op++ {
+		v := r.Intn(m.Values)
+		if r.Intn(5) < 2 {
+			_, ok := s.Delete(Int(v))
+			i := sort.SearchInts(model, v)
+			want := i < len(model) && model[i] == v
+			if want {
+				model = append(model[:i], model[i+1:]...)
+			}
+			if ok != want {
+				t.Fatalf("Delete(%v) ok = %v; want %v", v, ok, want)
+			}
+		} else {
+			s.Insert(Int(v))
+			i := sort.SearchInts(model, v)
+			model = append(model[:i], append([]int{v}, model[i:]...)...)
+		}
+		if s.Len() != len(model) {
+			t.Fatalf("Len = %v; want %v", s.Len(), len(model))
+		}
+		v = r.Intn(m.Values)
+		i := sort.SearchInts(model, v)
+		if got, want := s.Contains(Int(v)), i < len(model) && model[i] == v; got != want {
+			t.Fatalf("Contains(%v) = %v; want %v", v, got, want)
+		}
+		succ, ok := s.Successor(Int(v))
+		j := sort.SearchInts(model, v+1)
+		if wantOk := i < len(model) && model[i] == v && j < len(model); ok != wantOk || ok && int(succ.(Int)) != model[j] {
+			t.Fatalf("Successor(%v) = %v, %v; model %v", v, succ, ok, model)
+		}
+		if op%100 == 0 {
+			m.verify(t)
+		}
+	}
+	m.verify(t)
+	walked := ints(s.Walk())
+	if len(walked) != len(model) {
+		t.Fatalf("walked %v values; want %v", len(walked), len(model))
+	}
+	for i := range walked {
+		if walked[i] != model[i] {
+			t.Fatalf("Walk = %v; want %v", walked, model)
+		}
+	}
+	if len(model) > 0 {
+		min, _ := s.Minimum()
+		max, _ := s.Maximum()
+		if int(min.(Int)) != model[0] || int(max.(Int)) != model[len(model)-1] {
+			t.Errorf("Minimum, Maximum = %v, %v; want %v, %v", min, max, model[0], model[len(model)-1])
+		}
+	}
+	for len(model) > 0 {
+		i := r.Intn(len(model))
+		if _, ok := s.Delete(Int(model[i])); !ok {
+			t.Fatalf("Delete(%v) failed", model[i])
+		}
+		model = append(model[:i], model[i+1:]...)
+	}
+	m.verify(t)
+	if s.Len() != 0 {
+		t.Errorf("Len of emptied set = %v", s.Len())
+	}
+	if _, ok := s.Minimum(); ok {
+		t.Errorf("Minimum of emptied set returned ok")
+	}
+	if _, ok := s.Maximum(); ok {
+		t.Errorf("Maximum of emptied set returned ok")
+	}
+}
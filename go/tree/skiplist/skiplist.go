@@ -0,0 +1,187 @@
+// Package skiplist implements a randomized ordered set as a skip list:
+// a sorted linked list where every node also appears in a random number
+// of higher "express" lists, giving expected O(lg n) searches.
+//
+// A SkipList is safe for concurrent use. Queries take a read lock, so
+// any number of readers can proceed together between writes.
+package skiplist
+
+import (
+	"github.com/twmb/algoimpl/go/tree/binary"
+	"math/rand"
+	"sync"
+)
+
+const (
+	maxLevel = 32
+	// each level holds roughly 1/p of the nodes of the level below it
+	p = 4
+)
+
+// SkipList is an ordered set of binary.Comparable values.
+// It implements binary.OrderedSet.
+type SkipList struct {
+	mu    sync.RWMutex
+	head  node // head.next[i] is the first node on level i
+	level int  // number of levels in use
+	size  int
+	rand  *rand.Rand
+}
+
+type node struct {
+	next  []*node
+	value binary.Comparable
+}
+
+// Returns a new, empty skip list.
+func New() *SkipList {
+	return &SkipList{
+		head:  node{next: make([]*node, maxLevel)},
+		level: 1,
+		rand:  rand.New(rand.NewSource(rand.Int63())),
+	}
+}
+
+func (s *SkipList) randomLevel() int {
+	level := 1
+	for level < maxLevel && s.rand.Intn(p) == 0 {
+		level++
+	}
+	return level
+}
+
+// Returns the last node on each level whose value is less than value.
+// The nodes are written to update, which must have length maxLevel.
+func (s *SkipList) predecessors(value binary.Comparable, update []*node) {
+	current := &s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for current.next[i] != nil && current.next[i].value.CompareTo(value) < 0 {
+			current = current.next[i]
+		}
+		update[i] = current
+	}
+}
+
+// Returns the first node whose value is not less than value, or nil.
+func (s *SkipList) lowerBound(value binary.Comparable) *node {
+	current := &s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for current.next[i] != nil && current.next[i].value.CompareTo(value) < 0 {
+			current = current.next[i]
+		}
+	}
+	return current.next[0]
+}
+
+// Len returns the number of values in the skip list.
+func (s *SkipList) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.size
+}
+
+// Insert inserts value into the skip list before any equal values.
+// Expected running time O(lg n).
+func (s *SkipList) Insert(value binary.Comparable) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var update [maxLevel]*node
+	s.predecessors(value, update[:])
+	level := s.randomLevel()
+	for ; s.level < level; s.level++ {
+		update[s.level] = &s.head
+	}
+	n := &node{next: make([]*node, level), value: value}
+	for i := 0; i < level; i++ {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+	}
+	s.size++
+}
+
+// Delete removes one value equal to value from the skip list and returns it.
+// If the skip list does not contain value, ok is false.
+// Expected running time O(lg n).
+func (s *SkipList) Delete(value binary.Comparable) (deleted binary.Comparable, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var update [maxLevel]*node
+	s.predecessors(value, update[:])
+	n := update[0].next[0]
+	if n == nil || n.value.CompareTo(value) != 0 {
+		return nil, false
+	}
+	for i := range n.next {
+		update[i].next[i] = n.next[i]
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.size--
+	return n.value, true
+}
+
+// Returns true if the skip list contains the target Comparable.
+func (s *SkipList) Contains(target binary.Comparable) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	n := s.lowerBound(target)
+	return n != nil && n.value.CompareTo(target) == 0
+}
+
+// Returns the minimum value in the skip list.
+// If the skip list is empty, ok is false.
+func (s *SkipList) Minimum() (min binary.Comparable, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.head.next[0] == nil {
+		return nil, false
+	}
+	return s.head.next[0].value, true
+}
+
+// Returns the maximum value in the skip list.
+// If the skip list is empty, ok is false.
+func (s *SkipList) Maximum() (max binary.Comparable, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	current := &s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for current.next[i] != nil {
+			current = current.next[i]
+		}
+	}
+	if current == &s.head {
+		return nil, false
+	}
+	return current.value, true
+}
+
+// Returns the smallest value in the skip list that is greater than target.
+// ok is false if the skip list does not contain the target or if there is no successor.
+func (s *SkipList) Successor(target binary.Comparable) (succ binary.Comparable, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	n := s.lowerBound(target)
+	if n == nil || n.value.CompareTo(target) != 0 {
+		return nil, false
+	}
+	for n != nil && n.value.CompareTo(target) == 0 {
+		n = n.next[0]
+	}
+	if n == nil {
+		return nil, false
+	}
+	return n.value, true
+}
+
+// Returns an in order Comparable slice of the skip list.
+func (s *SkipList) Walk() []binary.Comparable {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	walked := make([]binary.Comparable, 0, s.size)
+	for n := s.head.next[0]; n != nil; n = n.next[0] {
+		walked = append(walked, n.value)
+	}
+	return walked
+}
@@ -0,0 +1,61 @@
+package skiplist
+
+import (
+	"github.com/twmb/algoimpl/go/tree/binary"
+	"github.com/twmb/algoimpl/go/tree/internal/settest"
+	"sync"
+	"testing"
+)
+
+type Int = settest.Int
+
+var _ binary.OrderedSet = New()
+
+func (s *SkipList) verify(t *testing.T) {
+	for i := 0; i < s.level; i++ {
+		count := 0
+		for n := s.head.next[i]; n != nil; n = n.next[i] {
+			if n.next[i] != nil && n.value.CompareTo(n.next[i].value) > 0 {
+				t.Errorf("level %v out of order: %v before %v", i, n.value, n.next[i].value)
+			}
+			count++
+		}
+		if i == 0 && count != s.size {
+			t.Errorf("level 0 has %v nodes; size is %v", count, s.size)
+		}
+	}
+	for i := s.level; i < maxLevel; i++ {
+		if s.head.next[i] != nil {
+			t.Errorf("unused level %v is not empty", i)
+		}
+	}
+}
+
+func TestAgainstModel(t *testing.T) {
+	s := New()
+	settest.Model{Seed: 29, Ops: 2000, Values: 100, Verify: s.verify}.Run(t, s)
+}
+
+func TestConcurrent(t *testing.T) {
+	s := New()
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				s.Insert(Int(i*4 + w))
+				s.Contains(Int(i))
+				s.Successor(Int(i))
+				if i%2 == 0 {
+					s.Delete(Int(i*4 + w))
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	s.verify(t)
+	if s.Len() != 1000 {
+		t.Errorf("Len = %v; want 1000", s.Len())
+	}
+}
@@ -0,0 +1,235 @@
+// Package treap implements a randomized binary search tree.
+// Every node has a random priority and the tree is kept heap ordered
+// on the priorities, which keeps the expected height at O(lg n)
+// no matter what order values are inserted in. A treap can also be
+// split around a value and merged back together in expected O(lg n).
+package treap
+
+import (
+	"errors"
+	"github.com/twmb/algoimpl/go/tree/binary"
+	"math/rand"
+)
+
+// Treap is an ordered set of binary.Comparable values.
+// It implements binary.OrderedSet.
+type Treap struct {
+	root *node
+	rand *rand.Rand // source of node priorities
+}
+
+type node struct {
+	left     *node
+	right    *node
+	value    binary.Comparable
+	priority int64
+	size     int // number of nodes in this subtree
+}
+
+// Returns a new, empty treap.
+func New() *Treap {
+	return NewSeeded(rand.Int63())
+}
+
+// Returns a new, empty treap whose node priorities are drawn from a
+// source seeded with seed, so that the same operations always build
+// the same tree.
+func NewSeeded(seed int64) *Treap {
+	return &Treap{rand: rand.New(rand.NewSource(seed))}
+}
+
+func size(n *node) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *node) update() {
+	n.size = size(n.left) + size(n.right) + 1
+}
+
+// Splits the subtree at n into the nodes for which goesLeft
+// returns true and the rest. goesLeft must be monotone over the ordering.
+func split(n *node, goesLeft func(binary.Comparable) bool) (l, r *node) {
+	if n == nil {
+		return nil, nil
+	}
+	if goesLeft(n.value) {
+		n.right, r = split(n.right, goesLeft)
+		n.update()
+		return n, r
+	}
+	l, n.left = split(n.left, goesLeft)
+	n.update()
+	return l, n
+}
+
+// Merges two subtrees where every value in l is <= every value in r.
+func merge(l, r *node) *node {
+	if l == nil {
+		return r
+	}
+	if r == nil {
+		return l
+	}
+	if l.priority > r.priority {
+		l.right = merge(l.right, r)
+		l.update()
+		return l
+	}
+	r.left = merge(l, r.left)
+	r.update()
+	return r
+}
+
+// Len returns the number of values in the treap.
+func (t *Treap) Len() int {
+	return size(t.root)
+}
+
+// Insert inserts value into the treap after any equal values.
+// Expected running time O(lg n).
+func (t *Treap) Insert(value binary.Comparable) {
+	l, r := split(t.root, func(v binary.Comparable) bool {
+		return v.CompareTo(value) <= 0
+	})
+	n := &node{value: value, priority: t.rand.Int63(), size: 1}
+	t.root = merge(merge(l, n), r)
+}
+
+func remove(n *node, value binary.Comparable) (*node, binary.Comparable, bool) {
+	if n == nil {
+		return nil, nil, false
+	}
+	var deleted binary.Comparable
+	var ok bool
+	c := value.CompareTo(n.value)
+	switch {
+	case c < 0:
+		n.left, deleted, ok = remove(n.left, value)
+	case c > 0:
+		n.right, deleted, ok = remove(n.right, value)
+	default:
+		return merge(n.left, n.right), n.value, true
+	}
+	n.update()
+	return n, deleted, ok
+}
+
+// Delete removes one value equal to value from the treap and returns it.
+// If the treap does not contain value, ok is false.
+// Expected running time O(lg n).
+func (t *Treap) Delete(value binary.Comparable) (deleted binary.Comparable, ok bool) {
+	t.root, deleted, ok = remove(t.root, value)
+	return deleted, ok
+}
+
+// Returns true if the treap contains the target Comparable.
+func (t *Treap) Contains(target binary.Comparable) bool {
+	current := t.root
+	for current != nil {
+		c := target.CompareTo(current.value)
+		switch {
+		case c < 0:
+			current = current.left
+		case c > 0:
+			current = current.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the minimum value in the treap.
+// If the treap is empty, ok is false.
+func (t *Treap) Minimum() (min binary.Comparable, ok bool) {
+	current := t.root
+	if current == nil {
+		return nil, false
+	}
+	for current.left != nil {
+		current = current.left
+	}
+	return current.value, true
+}
+
+// Returns the maximum value in the treap.
+// If the treap is empty, ok is false.
+func (t *Treap) Maximum() (max binary.Comparable, ok bool) {
+	current := t.root
+	if current == nil {
+		return nil, false
+	}
+	for current.right != nil {
+		current = current.right
+	}
+	return current.value, true
+}
+
+// Returns the smallest value in the treap that is greater than target.
+// ok is false if the treap does not contain the target or if there is no successor.
+func (t *Treap) Successor(target binary.Comparable) (succ binary.Comparable, ok bool) {
+	found := false
+	current := t.root
+	for current != nil {
+		c := target.CompareTo(current.value)
+		if c < 0 {
+			succ, ok = current.value, true
+			current = current.left
+		} else {
+			found = found || c == 0
+			current = current.right
+		}
+	}
+	if !found {
+		return nil, false
+	}
+	return succ, ok
+}
+
+func walkInOrder(n *node, walked []binary.Comparable) []binary.Comparable {
+	if n != nil {
+		walked = walkInOrder(n.left, walked)
+		walked = append(walked, n.value)
+		walked = walkInOrder(n.right, walked)
+	}
+	return walked
+}
+
+// Returns an in order Comparable slice of the treap.
+func (t *Treap) Walk() []binary.Comparable {
+	return walkInOrder(t.root, make([]binary.Comparable, 0, t.Len()))
+}
+
+// Split removes every value greater than or equal to key from t
+// and returns them in a new treap, whose priorities are seeded from t's.
+// Expected running time O(lg n).
+func (t *Treap) Split(key binary.Comparable) *Treap {
+	var r *node
+	t.root, r = split(t.root, func(v binary.Comparable) bool {
+		return v.CompareTo(key) < 0
+	})
+	right := NewSeeded(t.rand.Int63())
+	right.root = r
+	return right
+}
+
+// Merge moves every value in other into t, leaving other empty.
+// Every value in t must be less than or equal to every value in other,
+// otherwise Merge returns an error and neither treap is changed.
+// A treap cannot be merged into itself.
+// Expected running time O(lg n).
+func (t *Treap) Merge(other *Treap) error {
+	if other == t {
+		return errors.New("Merge: cannot merge a treap into itself")
+	}
+	max, ok := t.Maximum()
+	if min, otherOk := other.Minimum(); ok && otherOk && max.CompareTo(min) > 0 {
+		return errors.New("Merge: treap values overlap")
+	}
+	t.root = merge(t.root, other.root)
+	other.root = nil
+	return nil
+}
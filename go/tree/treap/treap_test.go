@@ -0,0 +1,116 @@
+package treap
+
+import (
+	"github.com/twmb/algoimpl/go/tree/binary"
+	"github.com/twmb/algoimpl/go/tree/internal/settest"
+	"math/rand"
+	"testing"
+)
+
+type Int = settest.Int
+
+var _ binary.OrderedSet = New()
+
+func verify(n *node, t *testing.T) {
+	if n == nil {
+		return
+	}
+	if n.size != size(n.left)+size(n.right)+1 {
+		t.Errorf("node %v has size %v, children sizes %v, %v", n.value, n.size, size(n.left), size(n.right))
+	}
+	for _, child := range []*node{n.left, n.right} {
+		if child != nil && child.priority > n.priority {
+			t.Errorf("child %v has higher priority than parent %v", child.value, n.value)
+		}
+	}
+	if n.left != nil && n.left.value.CompareTo(n.value) > 0 {
+		t.Errorf("Left child %v > parent %v", n.left.value, n.value)
+	}
+	if n.right != nil && n.right.value.CompareTo(n.value) < 0 {
+		t.Errorf("Right child %v < parent %v", n.right.value, n.value)
+	}
+	verify(n.left, t)
+	verify(n.right, t)
+}
+
+func ints(walked []binary.Comparable) []int {
+	r := make([]int, len(walked))
+	for i := range walked {
+		r[i] = int(walked[i].(Int))
+	}
+	return r
+}
+
+func TestAgainstModel(t *testing.T) {
+	tr := NewSeeded(29)
+	settest.Model{Seed: 29, Ops: 2000, Values: 100, Verify: func(t *testing.T) {
+		verify(tr.root, t)
+	}}.Run(t, tr)
+}
+
+// Returns whether a and b have the same shape, values and priorities.
+func sameTree(a, b *node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.value == b.value && a.priority == b.priority &&
+		sameTree(a.left, b.left) && sameTree(a.right, b.right)
+}
+
+func TestNewSeeded(t *testing.T) {
+	a, b := NewSeeded(7), NewSeeded(7)
+	for _, v := range rand.Perm(100) {
+		a.Insert(Int(v))
+		b.Insert(Int(v))
+	}
+	if !sameTree(a.root, b.root) {
+		t.Errorf("treaps with the same seed and inserts differ")
+	}
+	if !sameTree(a.Split(Int(50)).root, b.Split(Int(50)).root) {
+		t.Errorf("treaps split from treaps with the same seed differ")
+	}
+}
+
+func TestSplitMerge(t *testing.T) {
+	tr := NewSeeded(29)
+	for _, v := range rand.New(rand.NewSource(29)).Perm(50) {
+		tr.Insert(Int(v))
+		tr.Insert(Int(v))
+	}
+	right := tr.Split(Int(20))
+	verify(tr.root, t)
+	verify(right.root, t)
+	if tr.Len() != 40 || right.Len() != 60 {
+		t.Fatalf("Split lengths = %v, %v; want 40, 60", tr.Len(), right.Len())
+	}
+	if max, _ := tr.Maximum(); max.(Int) != 19 {
+		t.Errorf("left Maximum = %v; want 19", max)
+	}
+	if min, _ := right.Minimum(); min.(Int) != 20 {
+		t.Errorf("right Minimum = %v; want 20", min)
+	}
+	one := NewSeeded(1)
+	one.Insert(Int(1))
+	if err := one.Merge(one); err == nil || one.Len() != 1 {
+		t.Errorf("Merge of a treap into itself = %v, left %v values; want an error and 1", err, one.Len())
+	}
+	if err := right.Merge(tr); err == nil {
+		t.Errorf("Merge of overlapping treaps did not error")
+	}
+	if err := tr.Merge(right); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	verify(tr.root, t)
+	if tr.Len() != 100 || right.Len() != 0 {
+		t.Fatalf("Merge lengths = %v, %v; want 100, 0", tr.Len(), right.Len())
+	}
+	walked := ints(tr.Walk())
+	for i := range walked {
+		if walked[i] != i/2 {
+			t.Fatalf("Walk after Merge = %v", walked)
+		}
+	}
+	if empty := New(); empty.Split(Int(0)).Len() != 0 || empty.Merge(New()) != nil {
+		t.Errorf("Split or Merge of empty treaps failed")
+	}
+}
@@ -2,7 +2,6 @@ package various
 
 import (
 	"math/rand"
-	"time"
 )
 
 // returns the final pivot index
@@ -38,8 +37,9 @@ func selectOrder(i int, slice []int) int {
 
 // Returns the ith smallest element from a slice of integers.
 // Runs in expected O(n) time, O(n^2) worst time (unlikely)
+// The input slice is not modified. See NthElement to select in place
+// from any type with a seedable source of randomness.
 func SelectOrder(i int, slice []int) int {
-	rand.Seed(time.Now().Unix())
 	cslice := make([]int, len(slice)) // copy
 	copy(cslice, slice[:])
 	return selectOrder(i, cslice)
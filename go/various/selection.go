@@ -0,0 +1,129 @@
+package various
+
+import (
+	"github.com/twmb/algoimpl/go/tree/heap"
+	"iter"
+	"math/rand"
+	"sort"
+)
+
+// Returns a random int in [0, n) from r, or from the default source if r is nil.
+func intn(r *rand.Rand, n int) int {
+	if r == nil {
+		return rand.Intn(n)
+	}
+	return r.Intn(n)
+}
+
+// Partitions s around pivot so that s[:lt] is less than pivot,
+// s[lt:gt] is equal to it and s[gt:] is greater.
+func partition3[T any](s []T, pivot T, less func(a, b T) bool) (lt, gt int) {
+	i := 0
+	gt = len(s)
+	for i < gt {
+		switch {
+		case less(s[i], pivot):
+			s[lt], s[i] = s[i], s[lt]
+			lt++
+			i++
+		case less(pivot, s[i]):
+			gt--
+			s[i], s[gt] = s[gt], s[i]
+		default:
+			i++
+		}
+	}
+	return lt, gt
+}
+
+func insertionSort[T any](s []T, less func(a, b T) bool) {
+	for j := 1; j < len(s); j++ {
+		for i := j; i > 0 && less(s[i], s[i-1]); i-- {
+			s[i], s[i-1] = s[i-1], s[i]
+		}
+	}
+}
+
+// NthElement rearranges s in place so that s[n] is the element that
+// would be there if s were sorted by less, nothing before it is greater
+// and nothing after it is less. Pivots are chosen randomly with r,
+// or with the default source if r is nil; passing a seeded r makes the
+// result repeatable. Runs in expected O(n) time.
+func NthElement[T any](s []T, n int, less func(a, b T) bool, r *rand.Rand) {
+	_ = s[n] // panic early if n is out of range
+	for len(s) > 1 {
+		lt, gt := partition3(s, s[intn(r, len(s))], less)
+		switch {
+		case n < lt:
+			s = s[:lt]
+		case n >= gt:
+			s = s[gt:]
+			n -= gt
+		default:
+			return
+		}
+	}
+}
+
+// PartialSort rearranges s in place so that s[:k] holds the k smallest
+// elements of s in sorted order. The rest of s is left in no particular
+// order. Randomness is as in NthElement. Runs in expected
+// O(n + k lg k) time.
+func PartialSort[T any](s []T, k int, less func(a, b T) bool, r *rand.Rand) {
+	if k <= 0 {
+		return
+	}
+	if k < len(s) {
+		NthElement(s, k-1, less, r)
+	}
+	head := s[:min(k, len(s))]
+	sort.Slice(head, func(i, j int) bool { return less(head[i], head[j]) })
+}
+
+// TopK returns the k largest values of seq ordered by less, largest
+// first. Only k values are held at once, so seq can be a stream much
+// larger than memory. Runs in O(n lg k) time.
+func TopK[T any](seq iter.Seq[T], k int, less func(a, b T) bool) []T {
+	top := heap.NewTopK(k, less)
+	for v := range seq {
+		top.Push(v)
+	}
+	return top.Values()
+}
+
+// Moves the median of every group of five elements of s to the front
+// and returns the median of those medians, which is greater than and
+// less than at least 3/10 of s each.
+func medianOfMedians[T any](s []T, less func(a, b T) bool) T {
+	m := 0
+	for i := 0; i < len(s); i += 5 {
+		group := s[i:min(i+5, len(s))]
+		insertionSort(group, less)
+		s[m], s[i+len(group)/2] = s[i+len(group)/2], s[m]
+		m++
+	}
+	MedianOfMediansSelect(s[:m], m/2, less)
+	return s[m/2]
+}
+
+// MedianOfMediansSelect rearranges s like NthElement, but chooses every
+// pivot as the median of the medians of groups of five, which
+// guarantees O(n) time with no randomness. It is slower than
+// NthElement on average; use it when inputs may be adversarial or
+// results must not depend on a seed.
+func MedianOfMediansSelect[T any](s []T, n int, less func(a, b T) bool) {
+	_ = s[n]
+	for len(s) > 5 {
+		lt, gt := partition3(s, medianOfMedians(s, less), less)
+		switch {
+		case n < lt:
+			s = s[:lt]
+		case n >= gt:
+			s = s[gt:]
+			n -= gt
+		default:
+			return
+		}
+	}
+	insertionSort(s, less)
+}
@@ -0,0 +1,130 @@
+package various_test
+
+import (
+	"github.com/twmb/algoimpl/go/various"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func intLess(a, b int) bool { return a < b }
+
+func randomInts(r *rand.Rand, n, max int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = r.Intn(max)
+	}
+	return s
+}
+
+// Checks that s[n] is the nth smallest of want (sorted) and s is partitioned around it.
+func checkNth(t *testing.T, name string, s []int, n int, want []int) {
+	if s[n] != want[n] {
+		t.Fatalf("%s: [%d] = %v; want %v", name, n, s[n], want[n])
+	}
+	for i := range s {
+		if i < n && s[i] > s[n] || i > n && s[i] < s[n] {
+			t.Fatalf("%s: [%d] = %v on the wrong side of [%d] = %v", name, i, s[i], n, s[n])
+		}
+	}
+}
+
+func TestNthElement(t *testing.T) {
+	r := rand.New(rand.NewSource(44))
+	for _, size := range []int{1, 2, 5, 6, 100, 1000} {
+		for _, max := range []int{3, 1 << 20} {
+			in := randomInts(r, size, max)
+			want := append([]int(nil), in...)
+			sort.Ints(want)
+			for _, n := range []int{0, size / 2, size - 1} {
+				s := append([]int(nil), in...)
+				various.NthElement(s, n, intLess, r)
+				checkNth(t, "NthElement", s, n, want)
+				s = append([]int(nil), in...)
+				various.NthElement(s, n, intLess, nil)
+				checkNth(t, "NthElement with default source", s, n, want)
+				s = append([]int(nil), in...)
+				various.MedianOfMediansSelect(s, n, intLess)
+				checkNth(t, "MedianOfMediansSelect", s, n, want)
+			}
+		}
+	}
+}
+
+func TestNthElementSeeded(t *testing.T) {
+	in := randomInts(rand.New(rand.NewSource(44)), 1000, 1<<20)
+	a, b := append([]int(nil), in...), append([]int(nil), in...)
+	various.NthElement(a, 400, intLess, rand.New(rand.NewSource(1)))
+	various.NthElement(b, 400, intLess, rand.New(rand.NewSource(1)))
+	if !slices.Equal(a, b) {
+		t.Errorf("NthElement with the same seed gave different arrangements")
+	}
+}
+
+func TestPartialSort(t *testing.T) {
+	r := rand.New(rand.NewSource(44))
+	in := randomInts(r, 500, 100)
+	want := append([]int(nil), in...)
+	sort.Ints(want)
+	for _, k := range []int{0, 1, 10, 499, 500, 600} {
+		s := append([]int(nil), in...)
+		various.PartialSort(s, k, intLess, r)
+		k = min(k, len(s))
+		if !slices.Equal(s[:k], want[:k]) {
+			t.Errorf("PartialSort(k=%d) = %v; want %v", k, s[:k], want[:k])
+		}
+		rest := append([]int(nil), s[k:]...)
+		sort.Ints(rest)
+		if !slices.Equal(rest, want[k:]) {
+			t.Errorf("PartialSort(k=%d) lost elements", k)
+		}
+	}
+}
+
+func TestTopK(t *testing.T) {
+	in := randomInts(rand.New(rand.NewSource(44)), 1000, 1<<20)
+	got := various.TopK(slices.Values(in), 10, intLess)
+	sort.Sort(sort.Reverse(sort.IntSlice(in)))
+	if !slices.Equal(got, in[:10]) {
+		t.Errorf("TopK = %v; want %v", got, in[:10])
+	}
+}
+
+// Median of medians must stay linear on input that is already sorted,
+// reversed or all the same.
+func TestMedianOfMediansSelectLinear(t *testing.T) {
+	const n = 1 << 14
+	sorted, reversed, equal := make([]int, n), make([]int, n), make([]int, n)
+	for i := 0; i < n; i++ {
+		sorted[i], reversed[i] = i, n-i
+	}
+	for _, s := range [][]int{sorted, reversed, equal} {
+		comparisons := 0
+		various.MedianOfMediansSelect(s, n/2, func(a, b int) bool {
+			comparisons++
+			return a < b
+		})
+		if comparisons > 40*n {
+			t.Errorf("MedianOfMediansSelect made %d comparisons for %d elements", comparisons, n)
+		}
+	}
+}
+
+func benchmarkSelect(b *testing.B, sel func([]int)) {
+	in := randomInts(rand.New(rand.NewSource(44)), 1<<16, 1<<30)
+	s := make([]int, len(in))
+	for i := 0; i < b.N; i++ {
+		copy(s, in)
+		sel(s)
+	}
+}
+
+func BenchmarkNthElement(b *testing.B) {
+	r := rand.New(rand.NewSource(44))
+	benchmarkSelect(b, func(s []int) { various.NthElement(s, len(s)/2, intLess, r) })
+}
+
+func BenchmarkMedianOfMediansSelect(b *testing.B) {
+	benchmarkSelect(b, func(s []int) { various.MedianOfMediansSelect(s, len(s)/2, intLess) })
+}